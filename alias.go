@@ -0,0 +1,337 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+const _configTag = "config"
+
+// Caches whether each type has any config struct tags, since most don't.
+var _hasAliases sync.Map // map[reflect.Type]bool
+
+// A Deprecation describes a deprecated key found while populating a struct.
+type Deprecation struct {
+	// Path is the full, period-separated configuration path of the
+	// deprecated key.
+	Path string
+	// Replacement is the full path of the key to use instead. It's empty if
+	// the field itself is deprecated.
+	Replacement string
+	// Source and Line locate the deprecated key, as in ValidationError.
+	Source string
+	Line   int
+}
+
+func (d Deprecation) String() string {
+	msg := d.Path + " is deprecated"
+	if d.Replacement != "" {
+		msg += ", use " + d.Replacement + " instead"
+	}
+	switch {
+	case d.Source != "" && d.Line > 0:
+		msg = fmt.Sprintf("%s (from %s:%d)", msg, d.Source, d.Line)
+	case d.Source != "":
+		msg = fmt.Sprintf("%s (from %s)", msg, d.Source)
+	}
+	return msg
+}
+
+// OnDeprecation registers a function that Value.Populate calls each time it
+// finds a deprecated key. It's typically used to log a warning, so that
+// services still using old keys can be found and migrated. Without it,
+// deprecated keys are accepted silently.
+//
+// Struct fields mark keys as deprecated with a "config" tag. Fields may
+// accept their old names as aliases, and may themselves be deprecated:
+//
+//	type Config struct {
+//		Timeout time.Duration `yaml:"timeout" config:"alias=request_timeout,deprecated"`
+//		Legacy  bool          `config:"deprecated"`
+//	}
+//
+// Aliases are keys in the same mapping as the field. When populating, an
+// alias is treated as though it were the field's own key; setting both to
+// different values is an error. The "deprecated" option reports uses of the
+// aliases, or of the field itself if it has no aliases. To accept several old
+// names, repeat the alias option.
+func OnDeprecation(f func(Deprecation)) YAMLOption {
+	return optionFunc(func(c *config) {
+		c.deprecations = append(c.deprecations, f)
+	})
+}
+
+type fieldOptions struct {
+	aliases    []string
+	deprecated bool
+}
+
+func parseConfigTag(t reflect.Type, f reflect.StructField) (fieldOptions, error) {
+	var opts fieldOptions
+	tag, ok := f.Tag.Lookup(_configTag)
+	if !ok {
+		return opts, nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case opt == "":
+		case opt == "deprecated":
+			opts.deprecated = true
+		case strings.HasPrefix(opt, "alias="):
+			alias := strings.TrimPrefix(opt, "alias=")
+			if alias == "" || strings.Contains(alias, _separator) {
+				return opts, fmt.Errorf("invalid alias %q for field %s.%s", alias, t, f.Name)
+			}
+			opts.aliases = append(opts.aliases, alias)
+		default:
+			return opts, fmt.Errorf("unknown option %q in config tag of field %s.%s", opt, t, f.Name)
+		}
+	}
+	return opts, nil
+}
+
+// An aliasResolver rewrites aliased keys to their fields' keys and, if
+// report is set, reports deprecated keys. If the provider normalizes keys, it
+// also respells keys to match their fields.
+type aliasResolver struct {
+	provider *YAML
+	report   bool
+}
+
+// apply resolves the aliases for type t in val. Like applyDefaults, it copies
+// mappings and sequences before changing them.
+func (r *aliasResolver) apply(t reflect.Type, val interface{}, path []string) (interface{}, error) {
+	if t == nil || val == nil || !hasAliases(t) && r.provider.normalize == nil {
+		return val, nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.apply(t.Elem(), val, path)
+	case reflect.Struct:
+		m, ok := val.(map[interface{}]interface{})
+		if !ok || reflect.PtrTo(t).Implements(_unmarshalerType) {
+			return val, nil
+		}
+		edit := &mappingEdit{orig: m}
+		if err := r.applyStruct(t, edit, path); err != nil {
+			return nil, err
+		}
+		return edit.result(), nil
+	case reflect.Slice, reflect.Array:
+		s, ok := val.([]interface{})
+		if !ok {
+			return val, nil
+		}
+		copied := make([]interface{}, len(s))
+		for i := range s {
+			elem, err := r.apply(t.Elem(), s[i], extend(path, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			copied[i] = elem
+		}
+		return copied, nil
+	case reflect.Map:
+		m, ok := val.(map[interface{}]interface{})
+		if !ok {
+			return val, nil
+		}
+		copied := make(map[interface{}]interface{}, len(m))
+		for k, v := range m {
+			elem, err := r.apply(t.Elem(), v, extend(path, fmt.Sprint(k)))
+			if err != nil {
+				return nil, err
+			}
+			copied[k] = elem
+		}
+		return copied, nil
+	}
+	return val, nil
+}
+
+// applyStruct applies the aliases for a struct type to a mapping.
+// Inlined structs share their parent's mapping.
+func (r *aliasResolver) applyStruct(t reflect.Type, m *mappingEdit, path []string) error {
+	var errs error
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		if inline {
+			if f.Type.Kind() == reflect.Struct {
+				errs = multierr.Append(errs, r.applyStruct(f.Type, m, path))
+			}
+			continue
+		}
+
+		opts, err := parseConfigTag(t, f)
+		if err != nil {
+			return err
+		}
+		normalize := r.provider.normalize
+		m.respell(name, normalize)
+		fieldPath := extend(path, name)
+		if opts.deprecated && len(opts.aliases) == 0 {
+			if _, ok := m.get(name); ok {
+				r.deprecated(fieldPath, nil)
+			}
+		}
+		for _, alias := range opts.aliases {
+			if sameKey(alias, name, normalize) {
+				// The alias is just another spelling of the field's key.
+				continue
+			}
+			m.respell(alias, normalize)
+			v, ok := m.get(alias)
+			if !ok {
+				continue
+			}
+			aliasPath := extend(path, alias)
+			if opts.deprecated {
+				r.deprecated(aliasPath, fieldPath)
+			}
+			m.delete(alias)
+			if current, ok := m.get(name); ok {
+				if same, err := areSameYAML(current, v); err != nil || !same {
+					errs = multierr.Append(errs, r.provider.validationError(aliasPath, fmt.Errorf(
+						"conflicts with %s, which is also set", joinPath(fieldPath),
+					)))
+				}
+				continue
+			}
+			m.set(name, v)
+		}
+
+		if v, ok := m.get(name); ok {
+			v, err := r.apply(f.Type, v, fieldPath)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			m.set(name, v)
+		}
+	}
+	return errs
+}
+
+func (r *aliasResolver) deprecated(path, replacement []string) {
+	if !r.report || len(r.provider.deprecations) == 0 {
+		return
+	}
+	verr := r.provider.validationError(path, nil)
+	d := Deprecation{
+		Path:        verr.Path,
+		Replacement: joinPath(replacement),
+		Source:      verr.Source,
+		Line:        verr.Line,
+	}
+	for _, f := range r.provider.deprecations {
+		f(d)
+	}
+}
+
+// A mappingEdit copies a mapping the first time it's modified.
+type mappingEdit struct {
+	orig   map[interface{}]interface{}
+	copied map[interface{}]interface{}
+}
+
+func (e *mappingEdit) get(k interface{}) (interface{}, bool) {
+	if e.copied != nil {
+		v, ok := e.copied[k]
+		return v, ok
+	}
+	v, ok := e.orig[k]
+	return v, ok
+}
+
+func (e *mappingEdit) set(k, v interface{}) {
+	if e.copied == nil {
+		e.copied = copyMapping(e.orig)
+	}
+	e.copied[k] = v
+}
+
+func (e *mappingEdit) delete(k interface{}) {
+	if e.copied == nil {
+		e.copied = copyMapping(e.orig)
+	}
+	delete(e.copied, k)
+}
+
+// respell renames the key that's the same as k after normalization to k,
+// unless the mapping already has k itself.
+func (e *mappingEdit) respell(k string, normalize func(string) string) {
+	if normalize == nil {
+		return
+	}
+	if _, ok := e.get(k); ok {
+		return
+	}
+	want := normalize(k)
+	for existing, v := range e.result() {
+		if s, ok := existing.(string); ok && normalize(s) == want {
+			e.delete(existing)
+			e.set(k, v)
+			return
+		}
+	}
+}
+
+func (e *mappingEdit) result() map[interface{}]interface{} {
+	if e.copied != nil {
+		return e.copied
+	}
+	return e.orig
+}
+
+// hasAliases reports whether populating a type could involve any config
+// struct tags.
+func hasAliases(t reflect.Type) bool {
+	if cached, ok := _hasAliases.Load(t); ok {
+		return cached.(bool)
+	}
+	has := false
+	walkFields(t, nil, make(map[reflect.Type]bool), func(_ []string, t reflect.Type) bool {
+		if has || t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(_unmarshalerType) {
+			return false
+		}
+		if t.Kind() == reflect.Struct {
+			for i := 0; i < t.NumField(); i++ {
+				if _, ok := t.Field(i).Tag.Lookup(_configTag); ok {
+					has = true
+				}
+			}
+		}
+		return !has
+	})
+	_hasAliases.Store(t, has)
+	return has
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+type aliasBackend struct {
+	Host string `config:"alias=hostname,deprecated"`
+}
+
+type aliasConfig struct {
+	Timeout  time.Duration `yaml:"timeout" default:"1s" config:"alias=request_timeout,deprecated"`
+	Name     string        `config:"alias=service,alias=svc"`
+	Legacy   bool          `config:"deprecated"`
+	Backends []aliasBackend
+	ByName   map[string]aliasBackend `yaml:"by_name"`
+}
+
+func newAliasProvider(t testing.TB, src string, deprecations *[]Deprecation) *YAML {
+	p, err := NewYAML(
+		Source(strings.NewReader(src)),
+		OnDeprecation(func(d Deprecation) {
+			*deprecations = append(*deprecations, d)
+		}),
+	)
+	require.NoError(t, err, "couldn't create provider")
+	return p
+}
+
+func TestPopulateAliases(t *testing.T) {
+	var deprecations []Deprecation
+	p := newAliasProvider(t, `
+svc:
+  request_timeout: 5s
+  service: api
+  legacy: true
+  backends:
+    - hostname: a
+    - host: b
+  by_name:
+    c: {hostname: c}
+`, &deprecations)
+
+	var cfg aliasConfig
+	require.NoError(t, p.Get("svc").Populate(&cfg), "populate failed")
+	assert.Equal(t, aliasConfig{
+		Timeout:  5 * time.Second,
+		Name:     "api",
+		Legacy:   true,
+		Backends: []aliasBackend{{Host: "a"}, {Host: "b"}},
+		ByName:   map[string]aliasBackend{"c": {Host: "c"}},
+	}, cfg, "unexpected config")
+
+	assert.Equal(t, []Deprecation{
+		{Path: "svc.request_timeout", Replacement: "svc.timeout", Source: "source 1", Line: 3},
+		{Path: "svc.legacy", Source: "source 1", Line: 5},
+		{Path: "svc.backends.0.hostname", Replacement: "svc.backends.0.host", Source: "source 1", Line: 7},
+		{Path: "svc.by_name.c.hostname", Replacement: "svc.by_name.c.host", Source: "source 1", Line: 10},
+	}, deprecations, "unexpected deprecations")
+	assert.Equal(t,
+		"svc.request_timeout is deprecated, use svc.timeout instead (from source 1:3)",
+		deprecations[0].String(),
+		"unexpected deprecation message",
+	)
+
+	// Aliases don't change the provider's contents.
+	assert.Equal(t, "5s", p.Get("svc.request_timeout").Value(), "provider contents changed")
+	assert.False(t, p.Get("svc.timeout").HasValue(), "provider contents changed")
+}
+
+func TestPopulateAliasConflicts(t *testing.T) {
+	t.Run("same value", func(t *testing.T) {
+		var deprecations []Deprecation
+		p := newAliasProvider(t, "timeout: 5s\nrequest_timeout: 5s", &deprecations)
+		var cfg aliasConfig
+		require.NoError(t, p.Get(Root).Populate(&cfg), "populate failed")
+		assert.Equal(t, 5*time.Second, cfg.Timeout, "unexpected timeout")
+		assert.Len(t, deprecations, 1, "expected deprecated key to be reported")
+	})
+
+	t.Run("different values", func(t *testing.T) {
+		var deprecations []Deprecation
+		p := newAliasProvider(t, "timeout: 5s\nrequest_timeout: 10s\nservice: a\nsvc: b", &deprecations)
+		var cfg aliasConfig
+		err := p.Get(Root).Populate(&cfg)
+		require.Error(t, err, "expected conflicting keys to fail")
+		assert.Equal(t, []string{
+			"request_timeout: conflicts with timeout, which is also set (from source 1:2)",
+			"svc: conflicts with name, which is also set (from source 1:4)",
+		}, errorStrings(multierr.Errors(err)), "unexpected errors")
+	})
+}
+
+func TestPopulateAliasDefaults(t *testing.T) {
+	var deprecations []Deprecation
+	p := newAliasProvider(t, "name: svc", &deprecations)
+	var cfg aliasConfig
+	require.NoError(t, p.Get(Root).Populate(&cfg), "populate failed")
+	assert.Equal(t, time.Second, cfg.Timeout, "expected default when neither key is set")
+	assert.Empty(t, deprecations, "unexpected deprecations")
+}
+
+func TestPopulateAliasesWithoutHandler(t *testing.T) {
+	p, err := NewYAML(Source(strings.NewReader("request_timeout: 5s")))
+	require.NoError(t, err, "couldn't create provider")
+
+	v, err := p.Get(Root).WithDefault(map[string]string{"name": "svc"})
+	require.NoError(t, err, "WithDefault failed")
+	var cfg aliasConfig
+	require.NoError(t, v.Populate(&cfg), "populate failed")
+	assert.Equal(t, 5*time.Second, cfg.Timeout, "unexpected timeout")
+	assert.Equal(t, "svc", cfg.Name, "unexpected name")
+}
+
+func TestPopulateAllAliases(t *testing.T) {
+	var deprecations []Deprecation
+	p := newAliasProvider(t, "request_timeout: 5s\nname: [oops]", &deprecations)
+	var cfg aliasConfig
+	err := p.Get(Root).PopulateAll(&cfg)
+	require.Error(t, err, "expected populate to fail")
+	assert.Equal(t, []string{
+		"name: cannot unmarshal !!seq into string (from source 1:2)",
+	}, errorStrings(multierr.Errors(err)), "aliases shouldn't be reported as unknown fields")
+	assert.Len(t, deprecations, 1, "deprecations should be reported once")
+}
+
+func TestInvalidConfigTags(t *testing.T) {
+	p := mustYAML(t, "a: 1")
+	tests := []struct {
+		desc   string
+		target interface{}
+		err    string
+	}{
+		{
+			desc: "unknown option",
+			target: &struct {
+				A int `config:"renamed"`
+			}{},
+			err: `unknown option "renamed" in config tag of field struct { A int "config:\"renamed\"" }.A`,
+		},
+		{
+			desc: "empty alias",
+			target: &struct {
+				A int `config:"alias="`
+			}{},
+			err: `invalid alias ""`,
+		},
+		{
+			desc: "nested alias",
+			target: &struct {
+				A int `config:"alias=b.c"`
+			}{},
+			err: `invalid alias "b.c"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := p.Get(Root).Populate(tt.target)
+			require.Error(t, err, "expected invalid tag to fail")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error")
+		})
+	}
+}
+
+func errorStrings(errs []error) []string {
+	strs := make([]string, len(errs))
+	for i, err := range errs {
+		strs[i] = err.Error()
+	}
+	return strs
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Command config inspects layered YAML configuration using the same merge,
+// expansion, and strict-mode logic as go.uber.org/config.
+//
+// Usage:
+//
+//	config [-f file]... [--env-file file] [--permissive] command [args]
+//
+// Files passed with -f are merged in order, with later files taking
+// priority. Environment variables are expanded using the process
+// environment, or using the KEY=VALUE pairs in the file passed with
+// --env-file. The commands are:
+//
+//	merge             print the merged configuration
+//	get KEY           print the configuration at KEY
+//	validate          check that the sources merge and expand cleanly
+//	lint              list unquoted scalars that YAML reads surprisingly
+//	vars              list the environment variables the configuration uses
+//	diff [-key KEY] -f file...
+//	                  compare against a second set of layered files
+//	schema -type T [package]
+//	                  print a JSON Schema for the Go struct type T
+//
+// The schema command doesn't read any configuration. Instead, it builds and
+// runs a small program that passes T to config.SchemaFor, using the doc
+// comments in T's package as descriptions. The package defaults to the one
+// in the current directory, and it must be importable (not a main package).
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/config"
+	"go.uber.org/multierr"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// files is a flag.Value that collects repeated -f flags.
+type files []string
+
+func (f *files) String() string { return strings.Join(*f, ",") }
+
+func (f *files) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+type cli struct {
+	files      files
+	envFile    string
+	permissive bool
+	lookup     config.LookupFunc
+	out        io.Writer
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	c := &cli{out: stdout, lookup: os.LookupEnv}
+	flags := flag.NewFlagSet("config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&c.files, "f", "YAML `file` to merge; repeat in priority order")
+	flags.StringVar(&c.envFile, "env-file", "", "`file` of KEY=VALUE pairs to use instead of the environment")
+	flags.BoolVar(&c.permissive, "permissive", false, "disable strict mode")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: config [-f file]... [--env-file file] [--permissive] merge|get|validate|lint|vars|diff|schema [args]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	if c.envFile != "" {
+		env, err := readEnvFile(c.envFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "config: %v\n", err)
+			return 1
+		}
+		c.lookup = func(key string) (string, bool) {
+			v, ok := env[key]
+			return v, ok
+		}
+	}
+
+	var err error
+	cmd, rest := flags.Arg(0), flags.Args()[1:]
+	switch cmd {
+	case "merge":
+		err = c.get(config.Root)
+	case "get":
+		if len(rest) != 1 {
+			err = errors.New("get requires exactly one key")
+			break
+		}
+		err = c.get(rest[0])
+	case "validate":
+		err = c.validate()
+	case "lint":
+		err = c.lint()
+	case "vars":
+		err = c.vars()
+	case "diff":
+		err = c.diff(rest, stderr)
+	case "schema":
+		err = c.schema(rest, stderr)
+	default:
+		err = fmt.Errorf("unknown command %q", cmd)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "config: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *cli) provider(names []string, lookup config.LookupFunc, extra ...config.YAMLOption) (*config.YAML, error) {
+	opts := make([]config.YAMLOption, 0, len(names)+len(extra)+2)
+	for _, name := range names {
+		opts = append(opts, config.File(name))
+	}
+	opts = append(opts, config.Expand(lookup))
+	if c.permissive {
+		opts = append(opts, config.Permissive())
+	}
+	opts = append(opts, extra...)
+	return config.NewYAML(opts...)
+}
+
+func (c *cli) get(key string) error {
+	p, err := c.provider(c.files, c.lookup)
+	if err != nil {
+		return err
+	}
+	v := p.Get(key)
+	if !v.HasValue() {
+		return fmt.Errorf("no configuration at key %q", key)
+	}
+	out, err := v.Marshal(config.FormatYAML)
+	if err != nil {
+		return err
+	}
+	_, err = c.out.Write(out)
+	return err
+}
+
+func (c *cli) validate() error {
+	if _, err := c.provider(c.files, c.lookup); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(c.out, "ok")
+	return err
+}
+
+// lint prints each ambiguous scalar in the sources, failing if there are
+// any.
+func (c *cli) lint() error {
+	var found int
+	_, err := c.provider(c.files, c.lookup, config.Lint(func(a config.Ambiguity) {
+		found++
+		fmt.Fprintln(c.out, a)
+	}))
+	if found > 0 {
+		// In strict mode, err repeats the ambiguities we've already printed.
+		return fmt.Errorf("found %d ambiguous scalars", found)
+	}
+	return err
+}
+
+// vars lists the variables referenced by the merged configuration. Values
+// overridden during the merge are never expanded, so variables referenced
+// only in overridden values aren't listed. Unset variables without defaults
+// are listed too, and then reported as errors.
+func (c *cli) vars() error {
+	var (
+		referenced = make(map[string]bool)
+		missing    = make(map[string]bool) // unset and without a default
+		lastUnset  string
+		errs       error
+	)
+	record := func(key string) (string, bool) {
+		v, ok := c.lookup(key)
+		referenced[key] = ok
+		if missing[key] {
+			// Pretend the variable is set so that expansion can continue to
+			// the rest of the variables.
+			return "", true
+		}
+		if !ok {
+			lastUnset = key
+		}
+		return v, ok
+	}
+	for {
+		lastUnset = ""
+		_, err := c.provider(c.files, record)
+		if err == nil {
+			break
+		}
+		// Expansion stops at the first unset variable without a default,
+		// which is the last unset variable looked up.
+		if lastUnset == "" || !strings.Contains(err.Error(), strconv.Quote(lastUnset)) {
+			return err
+		}
+		missing[lastUnset] = true
+		errs = multierr.Append(errs, err)
+	}
+	names := make([]string, 0, len(referenced))
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		status := "set"
+		if !referenced[name] {
+			status = "unset"
+		}
+		if _, err := fmt.Fprintf(c.out, "%s\t%s\n", name, status); err != nil {
+			return err
+		}
+	}
+	return errs
+}
+
+func (c *cli) diff(args []string, stderr io.Writer) error {
+	var (
+		against files
+		key     string
+	)
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Var(&against, "f", "YAML `file` to compare against; repeat in priority order")
+	flags.StringVar(&key, "key", config.Root, "compare only the configuration at `key`")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(against) == 0 {
+		return errors.New("diff requires at least one -f file to compare against")
+	}
+
+	old, err := c.provider(c.files, c.lookup)
+	if err != nil {
+		return err
+	}
+	new, err := c.provider(against, c.lookup)
+	if err != nil {
+		return err
+	}
+	for _, change := range config.Diff(old, new, key) {
+		if _, err := fmt.Fprintln(c.out, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEnvFile reads KEY=VALUE pairs, one per line. Blank lines and lines
+// starting with # are ignored.
+func readEnvFile(name string) (map[string]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sep := strings.Index(line, "=")
+		if sep < 1 {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", name, n)
+		}
+		env[line[:sep]] = line[sep+1:]
+	}
+	return env, scanner.Err()
+}
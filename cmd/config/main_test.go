@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t testing.TB, dir, name, contents string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644), "couldn't write %s", name)
+	return path
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "base.yaml", "server: {port: 80, host: $HOST}\nname: ${NAME:svc}\n")
+	prod := writeFile(t, dir, "prod.yaml", "server: {port: 443}\n")
+	env := writeFile(t, dir, "env", "# comment\n\nHOST=example.com\n")
+	bad := writeFile(t, dir, "bad.yaml", "server: [1, 2]\n")
+	emptyEnv := writeFile(t, dir, "empty.env", "")
+	badEnv := writeFile(t, dir, "bad.env", "HOST\n")
+	noDefault := writeFile(t, dir, "no-default.yaml", "server: {port: $PORT}\n")
+	ambiguous := writeFile(t, dir, "ambiguous.yaml", "mode: 0755\nname: 'yes'\nduration: 1:30\n")
+
+	tests := []struct {
+		desc   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			desc:   "merge",
+			args:   []string{"-f", base, "-f", prod, "--env-file", env, "merge"},
+			stdout: "name: svc\nserver:\n  host: example.com\n  port: 443\n",
+		},
+		{
+			desc:   "get",
+			args:   []string{"-f", base, "-f", prod, "--env-file", env, "get", "server.port"},
+			stdout: "443\n",
+		},
+		{
+			desc:   "get missing",
+			args:   []string{"-f", base, "--env-file", env, "get", "not_there"},
+			code:   1,
+			stderr: `no configuration at key "not_there"`,
+		},
+		{
+			desc:   "get without key",
+			args:   []string{"-f", base, "get"},
+			code:   1,
+			stderr: "get requires exactly one key",
+		},
+		{
+			desc:   "validate",
+			args:   []string{"-f", base, "-f", prod, "--env-file", env, "validate"},
+			stdout: "ok\n",
+		},
+		{
+			desc:   "validate merge conflict",
+			args:   []string{"-f", base, "-f", bad, "--env-file", env, "validate"},
+			code:   1,
+			stderr: "can't merge a sequence into a mapping",
+		},
+		{
+			desc:   "validate permissive",
+			args:   []string{"-f", base, "-f", bad, "--env-file", env, "--permissive", "validate"},
+			stdout: "ok\n",
+		},
+		{
+			desc:   "validate unset variable",
+			args:   []string{"-f", base, "--env-file", emptyEnv, "validate"},
+			code:   1,
+			stderr: "couldn't expand environment",
+		},
+		{
+			desc:   "lint",
+			args:   []string{"-f", base, "--env-file", env, "lint"},
+			stdout: "",
+		},
+		{
+			desc:   "lint ambiguous",
+			args:   []string{"-f", base, "-f", ambiguous, "--env-file", env, "lint"},
+			code:   1,
+			stdout: "mode: unquoted 0755 is read as the integer 493 (from " + ambiguous + ":1:7)\n",
+			stderr: "found 1 ambiguous scalars",
+		},
+		{
+			desc:   "vars",
+			args:   []string{"-f", base, "--env-file", env, "vars"},
+			stdout: "HOST\tset\nNAME\tunset\n",
+		},
+		{
+			desc:   "vars unset without default",
+			args:   []string{"-f", base, "-f", noDefault, "--env-file", emptyEnv, "vars"},
+			code:   1,
+			stdout: "HOST\tunset\nNAME\tunset\nPORT\tunset\n",
+			stderr: `default is empty for "PORT"`,
+		},
+		{
+			desc:   "diff",
+			args:   []string{"-f", base, "--env-file", env, "diff", "-f", base, "-f", prod},
+			stdout: "modified server.port: 80 -> 443\n",
+		},
+		{
+			desc:   "diff key",
+			args:   []string{"-f", base, "--env-file", env, "diff", "-key", "name", "-f", base, "-f", prod},
+			stdout: "",
+		},
+		{
+			desc:   "diff without files",
+			args:   []string{"-f", base, "diff"},
+			code:   1,
+			stderr: "diff requires at least one -f file",
+		},
+		{
+			desc:   "bad env file",
+			args:   []string{"-f", base, "--env-file", badEnv, "merge"},
+			code:   1,
+			stderr: "bad.env:1: expected KEY=VALUE",
+		},
+		{
+			desc:   "unknown command",
+			args:   []string{"frobnicate"},
+			code:   1,
+			stderr: `unknown command "frobnicate"`,
+		},
+		{
+			desc:   "no command",
+			code:   2,
+			stderr: "usage: config",
+		},
+		{
+			desc:   "bad flag",
+			args:   []string{"--not-a-flag"},
+			code:   2,
+			stderr: "flag provided but not defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			code, stdout, stderr := runCLI(tt.args...)
+			assert.Equal(t, tt.code, code, "unexpected exit code, stderr: %s", stderr)
+			assert.Equal(t, tt.stdout, stdout, "unexpected stdout")
+			if tt.stderr != "" {
+				assert.True(t, strings.Contains(stderr, tt.stderr), "expected %q in stderr %q", tt.stderr, stderr)
+			}
+		})
+	}
+}
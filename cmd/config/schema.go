@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var _schemaProgram = template.Must(template.New("main.go").Parse(`// Code generated by go.uber.org/config/cmd/config. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"go.uber.org/config"
+	target {{ printf "%q" .ImportPath }}
+)
+
+func main() {
+	t := reflect.TypeOf((*target.{{ .Type }})(nil)).Elem()
+	schema, err := config.SchemaFor(t, config.GoComments({{ printf "%q" .Dir }}))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(schema)
+}
+`))
+
+type goPackage struct {
+	ImportPath string
+	Dir        string
+	Name       string
+	Type       string
+}
+
+func (c *cli) schema(args []string, stderr io.Writer) error {
+	var typeName string
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&typeName, "type", "", "name of the Go struct `type` to describe")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if typeName == "" {
+		return errors.New("schema requires a -type")
+	}
+	// The type name is spliced into the generated program.
+	if !token.IsIdentifier(typeName) {
+		return fmt.Errorf("-type %q isn't a Go identifier", typeName)
+	}
+	pattern := "."
+	switch flags.NArg() {
+	case 0:
+	case 1:
+		pattern = flags.Arg(0)
+	default:
+		return errors.New("schema accepts at most one package")
+	}
+
+	pkg, err := listPackage(pattern)
+	if err != nil {
+		return err
+	}
+	if pkg.Name == "main" {
+		return fmt.Errorf("can't import main package %s", pkg.ImportPath)
+	}
+	pkg.Type = typeName
+
+	tmp, err := ioutil.TempDir("", "config-schema")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	src := &bytes.Buffer{}
+	if err := _schemaProgram.Execute(src, pkg); err != nil {
+		return err
+	}
+	program := filepath.Join(tmp, "main.go")
+	if err := ioutil.WriteFile(program, src.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	// The program must appear to live inside the target's module so that its
+	// imports resolve. Rather than writing to the user's source tree, overlay
+	// it there; the go tool never creates the overlaid file.
+	virtual := filepath.Join(pkg.Dir, "config_schema", "main.go")
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {virtual: program},
+	})
+	if err != nil {
+		return err
+	}
+	overlayPath := filepath.Join(tmp, "overlay.json")
+	if err := ioutil.WriteFile(overlayPath, overlay, 0644); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "run", "-overlay", overlayPath, virtual)
+	cmd.Dir = pkg.Dir
+	cmd.Stdout = c.out
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("couldn't generate schema for %s.%s: %v", pkg.ImportPath, typeName, err)
+	}
+	return nil
+}
+
+func listPackage(pattern string) (*goPackage, error) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}\n{{.Dir}}\n{{.Name}}", pattern)
+	cmd.Stdout = out
+	cmd.Stderr = errOut
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("couldn't find package %s: %v: %s", pattern, err, strings.TrimSpace(errOut.String()))
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		return nil, fmt.Errorf("pattern %s must match exactly one package", pattern)
+	}
+	return &goPackage{ImportPath: lines[0], Dir: lines[1], Name: lines[2]}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("schema command builds a program with the go tool")
+	}
+
+	t.Run("success", func(t *testing.T) {
+		code, stdout, stderr := runCLI("schema", "-type", "Config", "./testdata/schema")
+		require.Equal(t, 0, code, "unexpected exit code, stderr: %s", stderr)
+
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &schema), "schema isn't valid JSON")
+		assert.Equal(t, "#/$defs/schema.Config", schema["$ref"], "unexpected root reference")
+		assert.Contains(t, stdout, "Port to listen on.", "expected field comment in schema")
+
+		entries, err := ioutil.ReadDir("./testdata/schema")
+		require.NoError(t, err, "couldn't list package directory")
+		assert.Len(t, entries, 1, "expected nothing to be written to the package directory")
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+		code, _, stderr := runCLI("schema", "./testdata/schema")
+		assert.Equal(t, 1, code, "unexpected exit code")
+		assert.Contains(t, stderr, "schema requires a -type", "unexpected error")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		code, _, stderr := runCLI("schema", "-type", "NotThere", "./testdata/schema")
+		assert.Equal(t, 1, code, "unexpected exit code")
+		assert.Contains(t, stderr, "couldn't generate schema", "unexpected error")
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		code, _, stderr := runCLI("schema", "-type", "Config)(nil)); os.Exit(2); ((*target.Config", "./testdata/schema")
+		assert.Equal(t, 1, code, "unexpected exit code")
+		assert.Contains(t, stderr, "isn't a Go identifier", "unexpected error")
+	})
+
+	t.Run("main package", func(t *testing.T) {
+		code, _, stderr := runCLI("schema", "-type", "cli", ".")
+		assert.Equal(t, 1, code, "unexpected exit code")
+		assert.Contains(t, stderr, "can't import main package", "unexpected error")
+	})
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"go.uber.org/multierr"
+	yaml "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// gopkg.in/yaml.v2 prefixes errors with lines in its own input, which aren't
+// meaningful to users.
+var _yamlLinePrefix = regexp.MustCompile(`^line \d+: `)
+
+// PopulateAll is like Populate, but if populating the target fails, it
+// reports every type mismatch, failed decode hook, and (in strict mode)
+// unknown field, rather than stopping at the first. Each problem is a
+// *ValidationError carrying the offending value's full configuration path
+// and, where possible, the source and line that defined it; use
+// multierr.Errors to inspect them individually.
+//
+// As with Populate, the target may be partially populated when PopulateAll
+// returns an error.
+func (v Value) PopulateAll(target interface{}) error {
+	err := v.Populate(target)
+	if err == nil {
+		return nil
+	}
+	if errs := v.provider.collectErrors(v.path, target); errs != nil {
+		return errs
+	}
+	// We couldn't pin the failure to any particular value.
+	return err
+}
+
+// collectErrors re-checks the configuration at path against the target's
+// type, value by value.
+func (y *YAML) collectErrors(path []string, target interface{}) error {
+	t := reflect.TypeOf(target)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil
+	}
+	// Populate already reported any deprecated keys.
+	val, ok, err := y.prepare(path, t, false)
+	if err != nil || !ok {
+		return nil
+	}
+	c := &errorCollector{
+		provider: y,
+		hooks:    newHookDecoder(y.hooks, y.v3),
+	}
+	c.check(t.Elem(), val, path)
+	return c.errs
+}
+
+type errorCollector struct {
+	provider *YAML
+	hooks    *hookDecoder
+	errs     error
+}
+
+func (c *errorCollector) add(path []string, err error) {
+	c.errs = multierr.Append(c.errs, c.provider.validationError(path, err))
+}
+
+// check descends through mappings and sequences as long as their shape
+// matches the target type, decoding everything else individually.
+func (c *errorCollector) check(t reflect.Type, val interface{}, path []string) {
+	if val == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	if reflect.PtrTo(t).Implements(_unmarshalerType) {
+		c.leaf(t, val, path)
+		return
+	}
+	val, done, _, err := c.hooks.convert(t, val, path)
+	if err != nil {
+		c.addDecodeError(path, err)
+		return
+	}
+	if done || val == nil {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		c.check(t.Elem(), val, path)
+	case reflect.Struct:
+		m, ok := val.(map[interface{}]interface{})
+		if !ok {
+			c.leaf(t, val, path)
+			return
+		}
+		c.checkStruct(t, m, path)
+	case reflect.Slice, reflect.Array:
+		s, ok := val.([]interface{})
+		if !ok || (t.Kind() == reflect.Array && len(s) != t.Len()) {
+			c.leaf(t, val, path)
+			return
+		}
+		for i, elem := range s {
+			c.check(t.Elem(), elem, extend(path, fmt.Sprint(i)))
+		}
+	case reflect.Map:
+		m, ok := val.(map[interface{}]interface{})
+		if !ok {
+			c.leaf(t, val, path)
+			return
+		}
+		c.checkMap(t, m, path, nil)
+	default:
+		c.leaf(t, val, path)
+	}
+}
+
+func (c *errorCollector) checkStruct(t reflect.Type, m map[interface{}]interface{}, path []string) {
+	known := make(map[interface{}]bool)
+	collectFieldNames(t, known)
+	inlineMap := c.checkFields(t, m, path, known)
+	if inlineMap || !c.provider.strict {
+		return
+	}
+	var names []string
+	for k := range known {
+		names = append(names, k.(string))
+	}
+	for _, k := range sortedKeys(m) {
+		if known[k] {
+			continue
+		}
+		hint := ""
+		if s, ok := k.(string); ok {
+			hint = didYouMean(suggest(s, names))
+		}
+		c.add(extend(path, fmt.Sprint(k)), fmt.Errorf("field %v not found in type %v%s", k, t, hint))
+	}
+}
+
+// checkFields checks the fields of a struct, including inlined structs and
+// maps, and reports whether it found an inlined map.
+func (c *errorCollector) checkFields(t reflect.Type, m map[interface{}]interface{}, path []string, known map[interface{}]bool) bool {
+	inlineMap := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		if inline {
+			switch f.Type.Kind() {
+			case reflect.Struct:
+				inlineMap = c.checkFields(f.Type, m, path, known) || inlineMap
+			case reflect.Map:
+				inlineMap = true
+				c.checkMap(f.Type, m, path, known)
+			}
+			continue
+		}
+		if v, ok := m[name]; ok {
+			c.check(f.Type, v, extend(path, name))
+		}
+	}
+	return inlineMap
+}
+
+// checkMap checks the keys and values of a mapping, skipping any keys
+// claimed by struct fields.
+func (c *errorCollector) checkMap(t reflect.Type, m map[interface{}]interface{}, path []string, skip map[interface{}]bool) {
+	for _, k := range sortedKeys(m) {
+		if skip[k] {
+			continue
+		}
+		elemPath := extend(path, fmt.Sprint(k))
+		c.leaf(t.Key(), k, elemPath)
+		c.check(t.Elem(), m[k], elemPath)
+	}
+}
+
+// leaf decodes a value on its own, recording any errors.
+func (c *errorCollector) leaf(t reflect.Type, val interface{}, path []string) {
+	if err := c.provider.decode(path, val, reflect.New(t).Interface()); err != nil {
+		c.addDecodeError(path, err)
+	}
+}
+
+func (c *errorCollector) addDecodeError(path []string, err error) {
+	var hookErr *hookError
+	msgs, isTypeErr := typeErrors(err)
+	switch {
+	case isTypeErr:
+		for _, msg := range msgs {
+			c.add(path, errors.New(_yamlLinePrefix.ReplaceAllString(msg, "")))
+		}
+	case errors.As(err, &hookErr):
+		c.add(hookErr.path, hookErr.err)
+	default:
+		c.add(path, err)
+	}
+}
+
+// typeErrors returns the individual messages of a *TypeError from either
+// gopkg.in/yaml.v2 or gopkg.in/yaml.v3.
+func typeErrors(err error) ([]string, bool) {
+	var (
+		v2 *yaml.TypeError
+		v3 *yamlv3.TypeError
+	)
+	switch {
+	case errors.As(err, &v2):
+		return v2.Errors, true
+	case errors.As(err, &v3):
+		return v3.Errors, true
+	default:
+		return nil, false
+	}
+}
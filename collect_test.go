@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+type collectBackend struct {
+	Host   string
+	Weight int
+}
+
+type collectServer struct {
+	Port     int
+	Timeout  time.Duration
+	Backends []collectBackend
+	Limits   map[string]int
+	MaxBody  ByteSize `yaml:"max_body"`
+}
+
+type collectConfig struct {
+	Server collectServer
+	Name   string
+}
+
+func TestPopulateAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`server:
+  port: abc
+  timeout: forever
+  tiemout: 1s
+  backends:
+    - host: a
+      weight: 1
+    - host: b
+      weight: heavy
+  limits:
+    rps: 100
+    burst: [1]
+  max_body: 10 parsecs
+name: svc
+`), 0644), "couldn't write config")
+
+	p, err := NewYAML(File(path))
+	require.NoError(t, err, "couldn't create provider")
+
+	var cfg collectConfig
+	err = p.Get(Root).PopulateAll(&cfg)
+	require.Error(t, err, "expected populate to fail")
+
+	var msgs []string
+	for _, e := range multierr.Errors(err) {
+		var verr *ValidationError
+		require.True(t, errors.As(e, &verr), "expected a *ValidationError, got %T", e)
+		assert.Equal(t, path, verr.Source, "unexpected source")
+		msgs = append(msgs, e.Error())
+	}
+	assert.Equal(t, []string{
+		"server.port: cannot unmarshal !!str `abc` into int (from " + path + ":2)",
+		`server.timeout: time: invalid duration "forever" (from ` + path + ":3)",
+		"server.backends.1.weight: cannot unmarshal !!str `heavy` into int (from " + path + ":9)",
+		"server.limits.burst: cannot unmarshal !!seq into int (from " + path + ":12)",
+		`server.max_body: invalid byte size "10 parsecs": unknown unit "parsecs" (from ` + path + ":13)",
+		"server.tiemout: field tiemout not found in type config.collectServer; did you mean timeout? (from " + path + ":4)",
+	}, msgs, "unexpected errors")
+}
+
+func TestPopulateAllSuccess(t *testing.T) {
+	p := mustYAML(t, "server: {port: 80, limits: {rps: 1}}\nname: svc")
+
+	var all, one collectConfig
+	require.NoError(t, p.Get(Root).PopulateAll(&all), "PopulateAll failed")
+	require.NoError(t, p.Get(Root).Populate(&one), "Populate failed")
+	assert.Equal(t, one, all, "PopulateAll and Populate should agree")
+}
+
+func TestPopulateAllPermissive(t *testing.T) {
+	p, err := NewYAML(Permissive(), Source(strings.NewReader("server: {port: x, extra: 1}")))
+	require.NoError(t, err, "couldn't create provider")
+
+	var cfg collectConfig
+	err = p.Get(Root).PopulateAll(&cfg)
+	require.Error(t, err, "expected populate to fail")
+	assert.Equal(t, []error{
+		&ValidationError{
+			Path:   "server.port",
+			Source: "source 1",
+			Line:   1,
+			Err:    errors.New("cannot unmarshal !!str `x` into int"),
+		},
+	}, multierr.Errors(err), "unknown fields shouldn't be reported in permissive mode")
+}
+
+func TestPopulateTypeMismatch(t *testing.T) {
+	src := "name: svc\n# The port\n# is\n# here:\nport: abc\n"
+	for _, v3 := range []bool{false, true} {
+		opts := []YAMLOption{Source(strings.NewReader(src))}
+		if v3 {
+			opts = append(opts, YAMLv3())
+		}
+		p, err := NewYAML(opts...)
+		require.NoError(t, err, "couldn't create provider")
+
+		var cfg struct {
+			Name string
+			Port int
+		}
+		err = p.Get(Root).Populate(&cfg)
+		require.Error(t, err, "expected populate to fail (v3: %v)", v3)
+		errs := multierr.Errors(err)
+		require.Len(t, errs, 1, "expected one error (v3: %v)", v3)
+		var verr *ValidationError
+		require.True(t, errors.As(errs[0], &verr), "expected a ValidationError, got %T (v3: %v)", errs[0], v3)
+		assert.Equal(t, "port", verr.Path, "unexpected path (v3: %v)", v3)
+		assert.Equal(t, 5, verr.Line, "expected the line in the source (v3: %v)", v3)
+		assert.Contains(t, verr.Err.Error(), "cannot unmarshal !!str `abc` into int", "unexpected error (v3: %v)", v3)
+		assert.NotContains(t, verr.Err.Error(), "line 2", "expected no line from the merged configuration (v3: %v)", v3)
+	}
+}
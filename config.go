@@ -24,11 +24,14 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"strings"
+	"reflect"
+	"strconv"
+	"sync"
 
 	"go.uber.org/config/internal/merge"
 	"go.uber.org/config/internal/unreachable"
 	yaml "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 const _separator = "."
@@ -47,10 +50,24 @@ const _separator = "."
 type YAML struct {
 	name     string
 	raw      [][]byte
+	names    []string   // names of the sources in raw
 	lookup   LookupFunc // see withDefault
 	contents interface{}
 	strict   bool
 	empty    bool
+
+	hooks        []decodeHook        // see DecodeHook
+	deprecations []func(Deprecation) // see OnDeprecation
+	normalize    func(string) string // see NormalizeKeys
+	v3           bool                // see YAMLv3
+
+	originsOnce sync.Once
+	originTree  *originNode // see origins
+
+	nodesOnce sync.Once
+	nodes     [][]*yamlv3.Node // see line
+
+	cfg config // options without sources, see withDefault
 }
 
 // NewYAML constructs a YAML provider. See the various YAMLOptions for
@@ -73,8 +90,13 @@ func NewYAML(options ...YAMLOption) (*YAML, error) {
 	// number of bugs, so we can't just selectively expand sources before
 	// merging.)
 	sourceBytes := make([][]byte, len(cfg.sources))
+	sourceNames := make([]string, len(cfg.sources))
 	for i := range cfg.sources {
 		s := cfg.sources[i]
+		sourceNames[i] = s.name
+		if s.name == "" {
+			sourceNames[i] = fmt.Sprintf("source %d", i+1)
+		}
 		if !s.raw {
 			sourceBytes[i] = s.bytes
 			continue
@@ -82,11 +104,26 @@ func NewYAML(options ...YAMLOption) (*YAML, error) {
 		sourceBytes[i] = escapeVariables(s.bytes)
 	}
 
+	if cfg.lint {
+		if err := lint(sourceBytes, sourceNames, cfg.strict, cfg.v3, cfg.lints); err != nil {
+			return nil, err
+		}
+	}
+
 	// On construction, go through a full merge-serialize-deserialize cycle to
 	// catch any duplicated keys as early as possible (in strict mode). It also
 	// strips comments, which stops us from attempting environment variable
 	// expansion. (We'll expand environment variables next.)
-	merged, err := merge.YAML(sourceBytes, cfg.strict)
+	var (
+		merged *bytes.Buffer
+		nodes  [][]*yamlv3.Node
+		err    error
+	)
+	if cfg.v3 {
+		merged, nodes, err = mergeV3(sourceBytes, cfg.strict, cfg.normalize)
+	} else {
+		merged, err = merge.NormalizedYAML(sourceBytes, cfg.strict, cfg.normalize)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("couldn't merge YAML sources: %v", err)
 	}
@@ -100,17 +137,38 @@ func NewYAML(options ...YAMLOption) (*YAML, error) {
 	y := &YAML{
 		name:   cfg.name,
 		raw:    sourceBytes,
+		names:  sourceNames,
 		lookup: cfg.lookup,
 		strict: cfg.strict,
+
+		hooks:        cfg.hooks,
+		deprecations: cfg.deprecations,
+		normalize:    cfg.normalize,
+		v3:           cfg.v3,
+
+		cfg: *cfg,
 	}
+	y.cfg.sources = nil
 
-	dec := yaml.NewDecoder(merged)
-	dec.SetStrict(cfg.strict)
-	if err := dec.Decode(&y.contents); err != nil {
-		if err != io.EOF {
+	if cfg.v3 {
+		// The sources have already been parsed, so keep their positions.
+		y.nodesOnce.Do(func() { y.nodes = nodes })
+		if y.contents, y.empty, err = decodeV3(merged, cfg.strict); err != nil {
 			return nil, fmt.Errorf("couldn't decode merged YAML: %v", err)
 		}
-		y.empty = true
+	} else {
+		dec := yaml.NewDecoder(merged)
+		dec.SetStrict(cfg.strict)
+		if err := dec.Decode(&y.contents); err != nil {
+			if err != io.EOF {
+				return nil, fmt.Errorf("couldn't decode merged YAML: %v", err)
+			}
+			y.empty = true
+		}
+	}
+
+	if err := y.validateSchemas(nil, y.contents, cfg.schemas); err != nil {
+		return nil, err
 	}
 
 	return y, nil
@@ -135,8 +193,24 @@ func (y *YAML) Name() string {
 //
 // To get a value holding the entire configuration, use the Root constant as
 // the key.
+//
+// Keys that contain periods can be addressed by escaping the periods with
+// backslashes or by quoting the key in brackets, so Get(`hosts.example\.com`)
+// and Get(`hosts["example.com"]`) are equivalent. Alternatively, use GetPath.
+// For compatibility, a key whose backslashes or brackets are part of the
+// mapping keys themselves still resolves, as long as the escaped reading
+// doesn't refer to any configuration.
 func (y *YAML) Get(key string) Value {
-	return y.get(strings.Split(key, _separator))
+	return y.get(y.resolvePath(nil, key))
+}
+
+// GetPath is like Get, but it takes a pre-split path instead of a key, so the
+// segments needn't be escaped. Calling GetPath with no segments returns the
+// entire configuration.
+func (y *YAML) GetPath(segments ...string) Value {
+	path := make([]string, len(segments))
+	copy(path, segments)
+	return Value{path: path, provider: y}
 }
 
 func (y *YAML) get(path []string) Value {
@@ -161,56 +235,224 @@ func (y *YAML) at(path []string) (interface{}, bool) {
 
 	cur := y.contents
 	for _, segment := range path {
-		// Cast to a mapping type. If this fails, then we ended up on a path
-		// that didn't terminate on a sequence or a scalar.
-		m, ok := cur.(map[interface{}]interface{})
+		next, ok := child(cur, segment, y.normalize)
 		if !ok {
 			return nil, false
 		}
+		cur = next
+	}
+	return cur, true
+}
 
-		// Try resolving the segment as a string and then unmarshal the path
-		// segment for a comparable key. After all, YAML scalar types are more
-		// than strings (boolean, integer, etc). We'll prefer a string form to
-		// resolve ambiguous paths.
-		if _, ok := m[segment]; !ok {
-			var key interface{}
-			if err := yaml.Unmarshal([]byte(segment), &key); err != nil {
-				return nil, false
-			}
-			if !merge.IsScalar(key) {
-				return nil, false
-			}
-			if _, ok := m[key]; !ok {
-				return nil, false
+// child looks up a path segment in a mapping or sequence. Sequences are
+// indexed by integers, with negative indexes counting back from the end.
+func child(cur interface{}, segment string, normalize func(string) string) (interface{}, bool) {
+	_, val, ok := lookup(cur, segment, normalize)
+	return val, ok
+}
+
+// lookup is like child, but also returns the canonical form of the segment:
+// the formatted mapping key or non-negative sequence index that matched.
+func lookup(cur interface{}, segment string, normalize func(string) string) (string, interface{}, bool) {
+	switch c := cur.(type) {
+	case map[interface{}]interface{}:
+		key, ok := resolveKey(c, segment, normalize)
+		if !ok {
+			return "", nil, false
+		}
+		return fmt.Sprint(key), c[key], true
+	case []interface{}:
+		i, ok := sequenceIndex(segment, len(c))
+		if !ok {
+			return "", nil, false
+		}
+		return strconv.Itoa(i), c[i], true
+	default:
+		// We ended up on a path that didn't terminate on a scalar or null.
+		return "", nil, false
+	}
+}
+
+// sequenceIndex parses an index into a sequence of length n, reporting
+// whether it's an integer in range.
+func sequenceIndex(segment string, n int) (int, bool) {
+	i, err := strconv.Atoi(segment)
+	if err != nil {
+		return 0, false
+	}
+	if i < 0 {
+		i += n
+	}
+	return i, i >= 0 && i < n
+}
+
+// indexError explains a missing value whose path indexes past the end of a
+// sequence. It returns nil if the value is missing for any other reason.
+func (y *YAML) indexError(path []string) error {
+	if y.empty {
+		return nil
+	}
+	cur := y.contents
+	for i, segment := range path {
+		if s, ok := cur.([]interface{}); ok {
+			if _, err := strconv.Atoi(segment); err == nil {
+				if _, ok := sequenceIndex(segment, len(s)); !ok {
+					return y.validationError(path[:i], fmt.Errorf(
+						"index %s out of range for sequence of length %d", segment, len(s),
+					))
+				}
 			}
-			cur = m[key]
-		} else {
-			cur = m[segment]
 		}
+		next, ok := child(cur, segment, y.normalize)
+		if !ok {
+			return nil
+		}
+		cur = next
 	}
-	return cur, true
+	return nil
+}
+
+// resolveKey finds the key in a mapping that a path segment refers to. A
+// segment matches a string key that's spelled the same way or, failing that,
+// a scalar key that it's the YAML representation of, like 1 or true. If
+// normalize isn't nil, it also matches a string key that's the same after
+// normalization (see NormalizeKeys).
+func resolveKey[V interface{}](m map[interface{}]V, segment string, normalize func(string) string) (interface{}, bool) {
+	if _, ok := m[segment]; ok {
+		return segment, true
+	}
+	var key interface{}
+	if err := yaml.Unmarshal([]byte(segment), &key); err == nil && merge.IsScalar(key) {
+		if _, ok := m[key]; ok {
+			return key, true
+		}
+	}
+	if normalize == nil {
+		return nil, false
+	}
+	want := normalize(segment)
+	for k := range m {
+		if s, ok := k.(string); ok && normalize(s) == want {
+			return k, true
+		}
+	}
+	return nil, false
 }
 
 func (y *YAML) populate(path []string, i interface{}) error {
-	val, ok := y.at(path)
+	val, ok, err := y.prepare(path, reflect.TypeOf(i), true)
+	if err != nil {
+		return err
+	}
 	if !ok {
+		if y.strict {
+			return y.indexError(path)
+		}
 		return nil
 	}
+	err = y.decode(path, val, i)
+	if _, ok := typeErrors(err); ok {
+		// The YAML library reports lines in the merged configuration, so
+		// explain where each problem is in the sources instead (and, for
+		// unknown fields, what was probably meant).
+		if errs := y.collectErrors(path, i); errs != nil {
+			return errs
+		}
+	}
+	return err
+}
+
+// prepare looks up the configuration at path and adjusts it for a target
+// of type t, resolving aliases and applying defaults. If report is set, it
+// reports any deprecated keys.
+func (y *YAML) prepare(path []string, t reflect.Type, report bool) (interface{}, bool, error) {
+	val, ok := y.at(path)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return val, ok, nil
+	}
+	if ok {
+		r := &aliasResolver{provider: y, report: report}
+		var err error
+		if val, err = r.apply(t.Elem(), val, path); err != nil {
+			return nil, false, err
+		}
+	}
+	return applyDefaults(t.Elem(), val, ok, y.v3)
+}
+
+// decode decodes unmarshalled configuration from the given path into i,
+// running any decode hooks.
+func (y *YAML) decode(path []string, val interface{}, i interface{}) error {
+	var hooks *hookDecoder
+	if t := reflect.TypeOf(i); t != nil && t.Kind() == reflect.Ptr {
+		hooks = newHookDecoder(y.hooks, y.v3)
+		var err error
+		val, _, err = hooks.decode(t.Elem(), val, path, nil)
+		if err != nil {
+			return err
+		}
+	}
 	buf := &bytes.Buffer{}
-	if err := yaml.NewEncoder(buf).Encode(val); err != nil {
+	if err := y.encode(buf, val); err != nil {
 		// Provider contents were produced by unmarshaling YAML, this isn't
 		// possible.
 		err := fmt.Errorf(
 			"couldn't marshal config at key %s to YAML: %v",
-			strings.Join(path, _separator),
+			joinPath(path),
 			err,
 		)
 		return unreachable.Wrap(err)
 	}
-	dec := yaml.NewDecoder(buf)
-	dec.SetStrict(y.strict)
 	// Decoding can't ever return EOF, since encoding any value is guaranteed to
 	// produce non-empty YAML.
+	if err := y.decodeInto(buf, i); err != nil {
+		return err
+	}
+	if hooks == nil {
+		return nil
+	}
+	return hooks.apply(reflect.ValueOf(i).Elem())
+}
+
+// encode serializes unmarshalled configuration with the provider's YAML
+// library.
+func (y *YAML) encode(w io.Writer, val interface{}) error {
+	marshal := yaml.Marshal
+	if y.v3 {
+		marshal = yamlv3.Marshal
+	}
+	bs, err := marshal(val)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bs)
+	return err
+}
+
+// decodeInto decodes serialized configuration into i with the provider's
+// YAML library.
+func (y *YAML) decodeInto(r io.Reader, i interface{}) error {
+	if y.v3 {
+		dec := yamlv3.NewDecoder(r)
+		dec.KnownFields(y.strict)
+		if top, ok := i.(*interface{}); ok {
+			// Keep the same representation as yaml.v2, which the rest of the
+			// package expects.
+			var n yamlv3.Node
+			if err := dec.Decode(&n); err != nil {
+				return err
+			}
+			val, err := nodeValue(&n, y.strict)
+			if err != nil {
+				return err
+			}
+			*top = val
+			return nil
+		}
+		return dec.Decode(i)
+	}
+	dec := yaml.NewDecoder(r)
+	dec.SetStrict(y.strict)
 	return dec.Decode(i)
 }
 
@@ -227,18 +469,14 @@ func (y *YAML) withDefault(d interface{}) (*YAML, error) {
 	// override all data provided by withDefault. To handle this correctly, we
 	// must use the new defaults as the lowest-priority source and re-merge the
 	// original sources.
-	opts := []YAMLOption{
-		Name(y.name),
-		Expand(y.lookup),
-		Source(rawDefault),
+	cfg := y.cfg
+	return NewYAML(
+		optionFunc(func(c *config) { *c = cfg }),
+		appendSources([][]byte{rawDefault.Bytes()}, []string{"default"}),
 		// y.raw contains the original sources with escaping for RawSources so
 		// appendSourcs won't double-expand them.
-		appendSources(y.raw),
-	}
-	if !y.strict {
-		opts = append(opts, Permissive())
-	}
-	return NewYAML(opts...)
+		appendSources(y.raw, y.names),
+	)
 }
 
 // A Value is a subset of a provider's configuration.
@@ -288,6 +526,18 @@ func (v Value) Source() string {
 // json.Unmarshal or yaml.Unmarshal. When populating a struct with some fields
 // already set, data is deep-merged as described in the package-level
 // documentation.
+//
+// Struct fields may declare defaults with a "default" tag, which is parsed
+// as YAML and used whenever the field's key is absent from the
+// configuration. See the package-level documentation for details.
+//
+// Decode hooks convert values into types like url.URL and net.IP that
+// gopkg.in/yaml.v2 can't handle on its own; see DecodeHook. To report every
+// problem with the configuration rather than just the first, use PopulateAll.
+//
+// Type mismatches and, in strict mode, unknown fields are reported as
+// *ValidationErrors combined with go.uber.org/multierr, so they identify the
+// source and line of each offending value.
 func (v Value) Populate(target interface{}) error {
 	return v.provider.populate(v.path, target)
 }
@@ -304,14 +554,24 @@ func (v Value) Populate(target interface{}) error {
 // then a call to Get("foo.bar") will hold the YAML mapping
 //
 //	baz: quux
+//
+// Segments also index into sequences, with negative indexes counting back
+// from the end: Get("servers.0.host") and Get("servers.-1.host") address the
+// first and last servers. In strict mode, populating a value whose path
+// indexes past the end of a sequence is an error.
 func (v Value) Get(path string) Value {
 	if path == Root {
 		return v
 	}
-	extended := make([]string, len(v.path))
-	copy(extended, v.path)
-	extended = append(extended, strings.Split(path, _separator)...)
-	return v.provider.get(extended)
+	return v.provider.get(v.provider.resolvePath(v.path, path))
+}
+
+// GetPath is like Get, but it takes a pre-split path relative to the value,
+// so the segments needn't be escaped.
+func (v Value) GetPath(segments ...string) Value {
+	extended := make([]string, 0, len(v.path)+len(segments))
+	extended = append(append(extended, v.path...), segments...)
+	return Value{path: extended, provider: v.provider}
 }
 
 // HasValue checks whether any configuration is available at this key.
@@ -361,9 +621,31 @@ func (v Value) Value() interface{} {
 // when applied multiple times. Instead, create a Go struct, set any defaults
 // directly on the struct, then call Populate.
 func (v Value) WithDefault(d interface{}) (Value, error) {
+	// Nest the default under the keys that the path actually resolves to, so
+	// that non-string keys like integers merge correctly.
+	keys := make([]interface{}, len(v.path))
+	cur, ok := v.provider.contents, !v.provider.empty
+	for i, segment := range v.path {
+		keys[i] = segment
+		if !ok {
+			continue
+		}
+		switch c := cur.(type) {
+		case map[interface{}]interface{}:
+			if key, found := resolveKey(c, segment, v.provider.normalize); found {
+				keys[i] = key
+			}
+		case []interface{}:
+			return Value{}, fmt.Errorf(
+				"can't apply a default within the sequence at %s", joinPath(v.path[:i]),
+			)
+		}
+		cur, ok = child(cur, segment, v.provider.normalize)
+	}
+
 	fallback := d
-	for i := len(v.path) - 1; i >= 0; i-- {
-		fallback = map[string]interface{}{v.path[i]: fallback}
+	for i := len(keys) - 1; i >= 0; i-- {
+		fallback = map[interface{}]interface{}{keys[i]: fallback}
 	}
 	p, err := v.provider.withDefault(fallback)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
 )
 
 func TestNewValueParameterValidation(t *testing.T) {
@@ -185,3 +186,141 @@ func TestSingleProviders(t *testing.T) {
 		run(t, p, err)
 	})
 }
+
+func TestSequenceIndexes(t *testing.T) {
+	p := mustYAML(t, `
+servers:
+  - host: a
+    ports: [80, 443]
+  - host: b
+matrix: [[1, 2], [3, 4]]
+byint: {0: zero}
+`)
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"servers.0.host", "a"},
+		{"servers.1.host", "b"},
+		{"servers.-1.host", "b"},
+		{"servers.-2.ports.-1", 443},
+		{"matrix.1.0", 3},
+		{"byint.0", "zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			v := p.Get(tt.key)
+			require.True(t, v.HasValue(), "expected a value")
+			assert.Equal(t, tt.want, v.Value(), "unexpected value")
+		})
+	}
+
+	t.Run("Value.Get", func(t *testing.T) {
+		servers := p.Get("servers")
+		assert.Equal(t, "b", servers.Get("1").Get("host").Value(), "unexpected value from chained Get")
+		assert.Equal(t, "b", servers.Get("-1.host").Value(), "unexpected value from nested Get")
+	})
+
+	for _, key := range []string{"servers.2.host", "servers.-3", "servers.host", "servers.0.host.0", "servers.1e0"} {
+		t.Run("missing "+key, func(t *testing.T) {
+			assert.False(t, p.Get(key).HasValue(), "expected no value")
+		})
+	}
+}
+
+func TestSequenceIndexErrors(t *testing.T) {
+	const src = "servers:\n  - host: a\n  - host: b\n"
+
+	p := mustYAML(t, src)
+	var host string
+	err := p.Get("servers.2.host").Populate(&host)
+	require.Error(t, err, "expected out-of-range index to fail in strict mode")
+	assert.Equal(t, "servers: index 2 out of range for sequence of length 2 (from source 1:1)", err.Error(), "unexpected error")
+
+	err = p.Get("servers.-3").Populate(&host)
+	require.Error(t, err, "expected out-of-range negative index to fail in strict mode")
+	assert.Contains(t, err.Error(), "index -3 out of range", "unexpected error")
+
+	assert.NoError(t, p.Get("servers.0.port").Populate(&host), "missing keys within elements aren't errors")
+	assert.NoError(t, p.Get("servers.name").Populate(&host), "non-integer segments aren't index errors")
+
+	permissive, err := NewYAML(Permissive(), Source(strings.NewReader(src)))
+	require.NoError(t, err, "couldn't create provider")
+	assert.NoError(t, permissive.Get("servers.2.host").Populate(&host), "permissive mode shouldn't fail")
+}
+
+func TestMultipleDocuments(t *testing.T) {
+	base := "name: api\nlimits: {rps: 1, burst: 2}\n---\nlimits: {rps: x}\n---\n"
+	override := "---\nlimits: {burst: 3}\n"
+	p, err := NewYAML(
+		Source(strings.NewReader(base)),
+		Source(strings.NewReader(override)),
+	)
+	require.NoError(t, err, "couldn't create provider")
+
+	assert.Equal(t, map[interface{}]interface{}{
+		"name":   "api",
+		"limits": map[interface{}]interface{}{"rps": "x", "burst": 3},
+	}, p.Get(Root).Value(), "later documents should override earlier ones")
+
+	assert.Equal(t, []Origin{
+		{Path: []string{"limits", "burst"}, Source: "source 2"},
+		{Path: []string{"limits", "rps"}, Source: "source 1"},
+		{Path: []string{"name"}, Source: "source 1"},
+	}, p.Get(Root).Origins(), "unexpected origins")
+
+	var limits struct{ RPS, Burst int }
+	verrs := multierr.Errors(p.Get("limits").PopulateAll(&limits))
+	require.Len(t, verrs, 1, "expected one error")
+	assert.Equal(t, "limits.rps: cannot unmarshal !!str `x` into int (from source 1:4)", verrs[0].Error(), "expected the line in the later document")
+}
+
+func TestSharedAnchors(t *testing.T) {
+	base := "defaults: &defaults\n  timeout: 1s\n  retries: 3\n"
+	override := "# Uses anchors from base.\nservice:\n  <<: *defaults\n  name: svc\nworker: *defaults\n"
+
+	tests := map[string][]YAMLOption{
+		"yaml.v2": nil,
+		"yaml.v3": {YAMLv3()},
+	}
+	for desc, extra := range tests {
+		t.Run(desc, func(t *testing.T) {
+			opts := []YAMLOption{
+				Source(strings.NewReader(base)),
+				Source(strings.NewReader(override)),
+			}
+			p, err := NewYAML(append(opts, extra...)...)
+			require.NoError(t, err, "couldn't create provider")
+
+			assert.Equal(t, map[interface{}]interface{}{
+				"name": "svc", "timeout": "1s", "retries": 3,
+			}, p.Get("service").Value(), "unexpected merge key result")
+			assert.Equal(t, p.Get("defaults").Value(), p.Get("worker").Value(), "unexpected alias result")
+
+			var worker struct {
+				Timeout string
+				Retries []int
+			}
+			verrs := multierr.Errors(p.Get("worker").PopulateAll(&worker))
+			require.Len(t, verrs, 1, "expected one error")
+			verr := verrs[0].(*ValidationError)
+			assert.Equal(t, "source 2", verr.Source, "expected the aliasing source")
+			assert.Equal(t, 5, verr.Line, "expected the alias's line")
+		})
+	}
+
+	_, err := NewYAML(Source(strings.NewReader(override)))
+	require.Error(t, err, "expected undefined aliases to fail in strict mode")
+	assert.Contains(t, err.Error(), "alias *defaults doesn't refer to an anchor", "unexpected error")
+
+	for _, v3 := range []bool{false, true} {
+		opts := []YAMLOption{Source(strings.NewReader("svc: *nope\n")), Permissive()}
+		if v3 {
+			opts = append(opts, YAMLv3())
+		}
+		_, err = NewYAML(opts...)
+		require.Error(t, err, "expected undefined aliases to fail in permissive mode (v3: %v)", v3)
+		assert.Contains(t, err.Error(), "alias *nope doesn't refer to an anchor", "unexpected error")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package confighttp serves the effective configuration of a provider over
+// HTTP, which is useful for debugging endpoints like /debug/config.
+package confighttp // import "go.uber.org/config/confighttp"
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/config"
+)
+
+const (
+	// GenerationHeader reports the number of successful reloads when the
+	// handler is backed by a *config.Reloadable.
+	GenerationHeader = "Config-Generation"
+	// ReloadErrorHeader reports the error from the most recent reload, if
+	// any, when the handler is backed by a *config.Reloadable.
+	ReloadErrorHeader = "Config-Reload-Error"
+)
+
+// reloader is implemented by *config.Reloadable.
+type reloader interface {
+	Generation() uint64
+	LastError() error
+}
+
+type handler struct {
+	provider config.Provider
+	opts     []config.MarshalOption
+}
+
+// NewHandler returns an http.Handler that serves the merged configuration of
+// the supplied provider. The marshal options are applied relative to the
+// root of the configuration, so config.Redact patterns and the struct passed
+// to config.RedactFields should describe the whole configuration even when
+// only a subtree is requested.
+//
+// The handler supports GET and HEAD requests with the following query
+// parameters:
+//
+//	key=a.b       serve only the configuration at key a.b
+//	format=json   serve JSON instead of the default YAML
+//	explain=1     instead of values, serve the name of the source that
+//	              supplied each value (see config.Value.Origins)
+//
+// If the provider is a *config.Reloadable, responses also include the
+// Config-Generation and Config-Reload-Error headers. If reloads keep
+// completing while the handler reads the configuration, it omits them rather
+// than risk describing a different generation than the body.
+func NewHandler(p config.Provider, opts ...config.MarshalOption) http.Handler {
+	return &handler{provider: p, opts: opts}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	format, contentType := config.FormatYAML, "text/yaml; charset=utf-8"
+	switch f := q.Get("format"); f {
+	case "", "yaml":
+	case "json":
+		format, contentType = config.FormatJSON, "application/json; charset=utf-8"
+	default:
+		http.Error(w, fmt.Sprintf("unknown format %q", f), http.StatusBadRequest)
+		return
+	}
+	explain, _ := strconv.ParseBool(q.Get("explain"))
+	key := q.Get("key")
+
+	// Capture a single snapshot, so that a concurrent reload can't change
+	// the configuration partway through the request.
+	root := h.snapshot(w.Header())
+
+	v := root.Get(key)
+	if !v.HasValue() {
+		http.Error(w, fmt.Sprintf("no configuration at key %q", key), http.StatusNotFound)
+		return
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+	if explain {
+		body, err = h.explain(v, key, format)
+	} else {
+		body, err = h.marshal(root, key, format)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(body)
+}
+
+// _snapshotAttempts bounds how many times snapshot retries when reloads race
+// with it.
+const _snapshotAttempts = 3
+
+// snapshot returns the root of the current configuration. If the provider is
+// reloadable, it also sets the generation and reload error headers, but only
+// reports the generation if no reload completed while it took the snapshot.
+func (h *handler) snapshot(header http.Header) config.Value {
+	rl, ok := h.provider.(reloader)
+	if !ok {
+		return h.provider.Get(config.Root)
+	}
+	var root config.Value
+	for i := 0; i < _snapshotAttempts; i++ {
+		before := rl.Generation()
+		root = h.provider.Get(config.Root)
+		err := rl.LastError()
+		if rl.Generation() != before {
+			continue
+		}
+		header.Set(GenerationHeader, strconv.FormatUint(before, 10))
+		if err != nil {
+			header.Set(ReloadErrorHeader, oneLine(err.Error()))
+		}
+		return root
+	}
+	// Reloads keep racing with us, so we can't tell which generation the
+	// snapshot belongs to.
+	return root
+}
+
+// marshal serializes the configuration at key, applying the marshal options
+// to the whole configuration.
+func (h *handler) marshal(root config.Value, key string, format config.Format) ([]byte, error) {
+	if len(h.opts) == 0 {
+		return root.Get(key).Marshal(format)
+	}
+	opts := make([]config.MarshalOption, 0, len(h.opts)+1)
+	opts = append(append(opts, h.opts...), config.Select(key))
+	return root.Marshal(format, opts...)
+}
+
+// explain serializes a mapping from each path in the value to the name of
+// the source that supplied it. Paths are formatted with config.JoinPath, so
+// they can be passed back to the handler's key parameter.
+func (h *handler) explain(v config.Value, key string, format config.Format) ([]byte, error) {
+	sources := make(map[string]string)
+	for _, o := range v.Origins() {
+		sources[explainKey(key, o.Path)] = o.Source
+	}
+	p, err := config.NewYAML(config.Static(sources))
+	if err != nil {
+		return nil, err
+	}
+	return p.Get(config.Root).Marshal(format)
+}
+
+// explainKey formats the path of an origin, which is relative to key, as a
+// key relative to the root.
+func explainKey(key string, path []string) string {
+	rel := config.JoinPath(path...)
+	switch {
+	case key == config.Root:
+		return rel
+	case rel == "":
+		return key
+	case strings.HasPrefix(rel, "["):
+		return key + rel
+	default:
+		return key + "." + rel
+	}
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package confighttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/config"
+)
+
+const _yaml = `
+db:
+  user: admin
+  password: hunter2
+  dsn: $$HOME/db
+server: {port: 80}
+`
+
+func serve(t testing.TB, h http.Handler, method, url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, url, nil))
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	p, err := config.NewYAML(config.Source(strings.NewReader(_yaml)))
+	require.NoError(t, err, "couldn't construct provider")
+	h := NewHandler(p, config.Redact("**.password"))
+
+	tests := []struct {
+		desc        string
+		method      string
+		url         string
+		code        int
+		contentType string
+		body        string
+	}{
+		{
+			desc:        "root",
+			url:         "/debug/config",
+			code:        http.StatusOK,
+			contentType: "text/yaml; charset=utf-8",
+			body:        "db:\n  dsn: $$HOME/db\n  password: <redacted>\n  user: admin\nserver:\n  port: 80\n",
+		},
+		{
+			desc:        "subtree as JSON",
+			url:         "/debug/config?key=db&format=json",
+			code:        http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			body:        "{\n  \"dsn\": \"$$HOME/db\",\n  \"password\": \"<redacted>\",\n  \"user\": \"admin\"\n}\n",
+		},
+		{
+			desc:        "redacted scalar",
+			url:         "/debug/config?key=db.password",
+			code:        http.StatusOK,
+			contentType: "text/yaml; charset=utf-8",
+			body:        "<redacted>\n",
+		},
+		{
+			desc:        "explain",
+			url:         "/debug/config?key=db&explain=1",
+			code:        http.StatusOK,
+			contentType: "text/yaml; charset=utf-8",
+			body:        "db.dsn: source 1\ndb.password: source 1\ndb.user: source 1\n",
+		},
+		{
+			desc: "missing key",
+			url:  "/debug/config?key=not_there",
+			code: http.StatusNotFound,
+			body: "no configuration at key \"not_there\"\n",
+		},
+		{
+			desc: "unknown format",
+			url:  "/debug/config?format=toml",
+			code: http.StatusBadRequest,
+			body: "unknown format \"toml\"\n",
+		},
+		{
+			desc:   "unsupported method",
+			method: http.MethodPost,
+			url:    "/debug/config",
+			code:   http.StatusMethodNotAllowed,
+			body:   "method not allowed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			w := serve(t, h, method, tt.url)
+			assert.Equal(t, tt.code, w.Code, "unexpected status code")
+			assert.Equal(t, tt.body, w.Body.String(), "unexpected body")
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"), "unexpected content type")
+			}
+			assert.Empty(t, w.Header().Get(GenerationHeader), "unexpected generation header")
+		})
+	}
+}
+
+func TestHandlerWithoutRedaction(t *testing.T) {
+	p, err := config.NewYAML(config.Source(strings.NewReader(_yaml)))
+	require.NoError(t, err, "couldn't construct provider")
+	w := serve(t, NewHandler(p), http.MethodGet, "/?key=db.password")
+	assert.Equal(t, "hunter2\n", w.Body.String(), "unexpected body")
+}
+
+func TestHandlerReloadable(t *testing.T) {
+	fail := false
+	r, err := config.NewReloadable(func() (config.Provider, error) {
+		if fail {
+			return nil, errors.New("can't read\nconfig")
+		}
+		return config.NewYAML(config.Source(strings.NewReader(_yaml)))
+	})
+	require.NoError(t, err, "couldn't construct provider")
+	h := NewHandler(r)
+
+	require.NoError(t, r.Reload(), "reload failed")
+	w := serve(t, h, http.MethodGet, "/?key=server.port")
+	assert.Equal(t, "80\n", w.Body.String(), "unexpected body")
+	assert.Equal(t, "1", w.Header().Get(GenerationHeader), "unexpected generation")
+	assert.Empty(t, w.Header().Get(ReloadErrorHeader), "unexpected reload error")
+
+	fail = true
+	require.Error(t, r.Reload(), "expected reload to fail")
+	w = serve(t, h, http.MethodHead, "/?key=server.port")
+	assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+	assert.Equal(t, "1", w.Header().Get(GenerationHeader), "unexpected generation")
+	assert.Equal(t, "can't read config", w.Header().Get(ReloadErrorHeader), "unexpected reload error")
+}
+
+// racingProvider reloads the configuration just after each read, as a
+// concurrent reload might, until it runs out of races.
+type racingProvider struct {
+	*config.Reloadable
+	races int
+}
+
+func (p *racingProvider) Get(key string) config.Value {
+	v := p.Reloadable.Get(key)
+	if p.races > 0 {
+		p.races--
+		p.Reload()
+	}
+	return v
+}
+
+func TestHandlerReloadRace(t *testing.T) {
+	port := 80
+	r, err := config.NewReloadable(func() (config.Provider, error) {
+		port++
+		return config.NewYAML(config.Static(map[string]int{"port": port}))
+	})
+	require.NoError(t, err, "couldn't construct provider")
+
+	t.Run("retry", func(t *testing.T) {
+		h := NewHandler(&racingProvider{Reloadable: r, races: 1})
+		w := serve(t, h, http.MethodGet, "/?key=port")
+		assert.Equal(t, "82\n", w.Body.String(), "unexpected body")
+		assert.Equal(t, "1", w.Header().Get(GenerationHeader), "expected the generation of the body")
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		h := NewHandler(&racingProvider{Reloadable: r, races: _snapshotAttempts})
+		w := serve(t, h, http.MethodGet, "/?key=port")
+		assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+		assert.Empty(t, w.Header().Get(GenerationHeader), "expected no generation header")
+	})
+}
+
+func TestHandlerProviderOptions(t *testing.T) {
+	p, err := config.NewYAML(
+		config.Source(strings.NewReader("DB:\n  Password: hunter2\n  host.name: localhost\n")),
+		config.NormalizeKeys(),
+	)
+	require.NoError(t, err, "couldn't construct provider")
+	h := NewHandler(p, config.Redact("DB.Password"))
+
+	w := serve(t, h, http.MethodGet, "/?key=db")
+	assert.Equal(t, http.StatusOK, w.Code, "unexpected status code")
+	assert.Equal(t, "Password: <redacted>\nhost.name: localhost\n", w.Body.String(), "unexpected body")
+
+	w = serve(t, h, http.MethodGet, "/?key=db&explain=1")
+	assert.Equal(t, "db.Password: source 1\ndb[\"host.name\"]: source 1\n", w.Body.String(), "unexpected body")
+
+	// Explained keys can be passed back to the handler.
+	w = serve(t, h, http.MethodGet, `/?key=`+url.QueryEscape(`db["host.name"]`))
+	assert.Equal(t, "localhost\n", w.Body.String(), "unexpected body")
+}
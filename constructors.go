@@ -22,7 +22,6 @@ package config
 
 import (
 	"bytes"
-	"fmt"
 	"io"
 )
 
@@ -35,7 +34,9 @@ type scopedProvider struct {
 var _ Provider = (*scopedProvider)(nil)
 
 func (s *scopedProvider) Get(key string) Value {
-	return s.Provider.Get(fmt.Sprintf("%s.%s", s.prefix, key))
+	// Resolve the key relative to the prefix's value, rather than joining the
+	// two strings, so that escaped and bracketed keys keep their meaning.
+	return s.Provider.Get(s.prefix).Get(key)
 }
 
 // NewScopedProvider wraps a provider and adds a prefix to all Get calls.
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const _defaultTag = "default"
+
+var (
+	_unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+	// Caches whether each type has any default struct tags, since most don't.
+	_hasDefaults sync.Map // map[reflect.Type]bool
+)
+
+// applyDefaults fills in default values from struct tags for any keys absent
+// from the configuration. It's given the type being populated, the
+// unmarshalled configuration, and whether any configuration was found, and
+// it returns the configuration with defaults applied. Default tags are parsed
+// with gopkg.in/yaml.v3 if v3 is set (see YAMLv3). To avoid modifying the
+// provider's contents, mappings and sequences are copied before they're
+// modified.
+//
+// Defaults are applied within struct fields, the elements of slices and
+// arrays, and the values of maps. Absent pointer fields stay nil rather than
+// being allocated just to hold defaults.
+func applyDefaults(t reflect.Type, val interface{}, found, v3 bool) (interface{}, bool, error) {
+	if t == nil || !hasDefaults(t) {
+		return val, found, nil
+	}
+	if t.Kind() == reflect.Ptr {
+		if !found {
+			return val, found, nil
+		}
+		return applyDefaults(t.Elem(), val, found, v3)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if !found {
+			val = make(map[interface{}]interface{})
+		}
+		m, ok := val.(map[interface{}]interface{})
+		if !ok {
+			// Let yaml.v2 report the type mismatch, if any.
+			return val, found, nil
+		}
+		m = copyMapping(m)
+		if err := applyStructDefaults(t, m, v3); err != nil {
+			return nil, false, err
+		}
+		if !found && len(m) == 0 {
+			return nil, false, nil
+		}
+		return m, true, nil
+	case reflect.Slice, reflect.Array:
+		s, ok := val.([]interface{})
+		if !ok {
+			return val, found, nil
+		}
+		copied := make([]interface{}, len(s))
+		for i := range s {
+			elem, _, err := applyDefaults(t.Elem(), s[i], true, v3)
+			if err != nil {
+				return nil, false, err
+			}
+			copied[i] = elem
+		}
+		return copied, found, nil
+	case reflect.Map:
+		m, ok := val.(map[interface{}]interface{})
+		if !ok {
+			return val, found, nil
+		}
+		copied := make(map[interface{}]interface{}, len(m))
+		for k, v := range m {
+			elem, _, err := applyDefaults(t.Elem(), v, true, v3)
+			if err != nil {
+				return nil, false, err
+			}
+			copied[k] = elem
+		}
+		return copied, found, nil
+	}
+	return val, found, nil
+}
+
+// applyStructDefaults applies the defaults for a struct type to a mapping
+// in place. Inlined structs share their parent's mapping.
+func applyStructDefaults(t reflect.Type, m map[interface{}]interface{}, v3 bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		if inline {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := applyStructDefaults(ft, m, v3); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		v, found := m[name]
+		if tag, ok := f.Tag.Lookup(_defaultTag); ok && !found {
+			var err error
+			if v, err = parseDefault(tag, v3); err != nil {
+				return fmt.Errorf("invalid default for field %s.%s: %v", t, f.Name, err)
+			}
+			found = true
+		}
+		v, found, err := applyDefaults(f.Type, v, found, v3)
+		if err != nil {
+			return err
+		}
+		if found {
+			m[name] = v
+		}
+	}
+	return nil
+}
+
+// parseDefault parses a default struct tag with the same parser as the
+// provider's sources.
+func parseDefault(tag string, v3 bool) (interface{}, error) {
+	if v3 {
+		v, _, err := decodeV3(bytes.NewReader([]byte(tag)), true /* strict */)
+		return v, err
+	}
+	var v interface{}
+	err := yaml.Unmarshal([]byte(tag), &v)
+	return v, err
+}
+
+// hasDefaults reports whether populating a type could involve any default
+// struct tags.
+func hasDefaults(t reflect.Type) bool {
+	if cached, ok := _hasDefaults.Load(t); ok {
+		return cached.(bool)
+	}
+	has := false
+	walkFields(t, nil, make(map[reflect.Type]bool), func(_ []string, t reflect.Type) bool {
+		if has || t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(_unmarshalerType) {
+			return false
+		}
+		if t.Kind() == reflect.Struct {
+			for i := 0; i < t.NumField(); i++ {
+				if _, ok := t.Field(i).Tag.Lookup(_defaultTag); ok {
+					has = true
+				}
+			}
+		}
+		return !has
+	})
+	_hasDefaults.Store(t, has)
+	return has
+}
+
+func copyMapping(m map[interface{}]interface{}) map[interface{}]interface{} {
+	copied := make(map[interface{}]interface{}, len(m)+1)
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
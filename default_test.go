@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsBackend struct {
+	Host    string
+	Timeout time.Duration `default:"1s"`
+	Tags    []string      `default:"[primary]"`
+}
+
+type defaultsInline struct {
+	Region string `default:"us-east"`
+}
+
+type defaultsConfig struct {
+	defaultsInline `yaml:",inline"`
+
+	Name     string `default:"svc"`
+	Backends []defaultsBackend
+	ByName   map[string]defaultsBackend `yaml:"by_name"`
+	Limits   map[string]int             `default:"{rps: 100}"`
+	Primary  defaultsBackend
+	Fallback *defaultsBackend
+	Nullable *string `default:"set"`
+}
+
+func TestPopulateDefaults(t *testing.T) {
+	p := mustYAML(t, `
+svc:
+  name: api
+  backends:
+    - host: a
+    - host: b
+      timeout: 5s
+  by_name:
+    c: {host: c, tags: []}
+  fallback: {host: d}
+  nullable: ~
+`)
+
+	var cfg defaultsConfig
+	require.NoError(t, p.Get("svc").Populate(&cfg), "populate failed")
+	assert.Equal(t, defaultsConfig{
+		defaultsInline: defaultsInline{Region: "us-east"},
+		Name:           "api",
+		Backends: []defaultsBackend{
+			{Host: "a", Timeout: time.Second, Tags: []string{"primary"}},
+			{Host: "b", Timeout: 5 * time.Second, Tags: []string{"primary"}},
+		},
+		ByName: map[string]defaultsBackend{
+			"c": {Host: "c", Timeout: time.Second, Tags: []string{}},
+		},
+		Limits:   map[string]int{"rps": 100},
+		Primary:  defaultsBackend{Timeout: time.Second, Tags: []string{"primary"}},
+		Fallback: &defaultsBackend{Host: "d", Timeout: time.Second, Tags: []string{"primary"}},
+	}, cfg, "unexpected populated struct")
+
+	// Defaults shouldn't leak into the provider's contents.
+	assert.Equal(t, []interface{}{
+		map[interface{}]interface{}{"host": "a"},
+		map[interface{}]interface{}{"host": "b", "timeout": "5s"},
+	}, p.Get("svc.backends").Value(), "unexpected contents")
+}
+
+func TestPopulateDefaultsMissing(t *testing.T) {
+	p := mustYAML(t, "foo: bar")
+
+	var backend defaultsBackend
+	require.NoError(t, p.Get("not_there").Populate(&backend), "populate failed")
+	assert.Equal(t, defaultsBackend{Timeout: time.Second, Tags: []string{"primary"}}, backend, "unexpected populated struct")
+
+	var backends []defaultsBackend
+	require.NoError(t, p.Get("not_there").Populate(&backends), "populate failed")
+	assert.Nil(t, backends, "expected missing slice to stay nil")
+
+	type noDefaults struct{ Foo string }
+	cfg := noDefaults{Foo: "preset"}
+	require.NoError(t, p.Get("not_there").Populate(&cfg), "populate failed")
+	assert.Equal(t, "preset", cfg.Foo, "expected missing value to leave struct alone")
+}
+
+func TestPopulateDefaultsOverridePresetValues(t *testing.T) {
+	p := mustYAML(t, "backend: {host: a}")
+
+	backend := defaultsBackend{Host: "preset", Timeout: time.Minute, Tags: []string{"preset"}}
+	require.NoError(t, p.Get("backend").Populate(&backend), "populate failed")
+	assert.Equal(t, defaultsBackend{
+		Host:    "a",
+		Timeout: time.Second,
+		Tags:    []string{"primary"},
+	}, backend, "expected tag defaults to replace values set on the struct")
+}
+
+func TestPopulateDefaultsErrors(t *testing.T) {
+	p := mustYAML(t, "foo: {}")
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		type cfg struct {
+			Bad []string `default:"[unclosed"`
+		}
+		err := p.Get("foo").Populate(&cfg{})
+		require.Error(t, err, "expected populate to fail")
+		assert.Contains(t, err.Error(), "invalid default for field config.cfg.Bad", "unexpected error")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		type cfg struct {
+			Port int `default:"eighty"`
+		}
+		assert.Error(t, p.Get("foo").Populate(&cfg{}), "expected populate to fail")
+	})
+}
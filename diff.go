@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"go.uber.org/config/internal/merge"
+)
+
+// A ChangeKind describes how a value differs between two configurations.
+type ChangeKind int
+
+const (
+	// Added indicates that a value is present only in the newer
+	// configuration.
+	Added ChangeKind = iota + 1
+	// Removed indicates that a value is present only in the older
+	// configuration.
+	Removed
+	// Modified indicates that a value is present in both configurations, but
+	// with different contents.
+	Modified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Modified:
+		return "modified"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// A Change is a single difference between two configurations.
+type Change struct {
+	// Path is the location of the change, relative to the key passed to Diff.
+	// Mapping keys and sequence indexes are represented by their string
+	// forms. Elements of sequences compared by a declared key (see DiffKey)
+	// are represented as "field=value".
+	Path []string
+	Kind ChangeKind
+	// Old and New hold the unmarshalled configuration before and after the
+	// change. Old is nil for additions, and New is nil for removals.
+	Old interface{}
+	New interface{}
+}
+
+func (c Change) String() string {
+	path := joinPath(c.Path)
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("%s %s: %v", c.Kind, path, c.New)
+	case Removed:
+		return fmt.Sprintf("%s %s: %v", c.Kind, path, c.Old)
+	default:
+		return fmt.Sprintf("%s %s: %v -> %v", c.Kind, path, c.Old, c.New)
+	}
+}
+
+// A DiffOption alters the default behavior of Diff.
+type DiffOption interface {
+	apply(*differ)
+}
+
+type diffOptionFunc func(*differ)
+
+func (f diffOptionFunc) apply(d *differ) { f(d) }
+
+// DiffKey compares the elements of the sequences at path by the value of
+// their field entry rather than by index, so that reordering elements isn't
+// reported as a change. The path is a pattern relative to the key passed to
+// Diff, with the same syntax as Value.Query.
+//
+// If any element of either sequence isn't a mapping with a scalar value for
+// field, or if values of field aren't unique, the sequences are compared by
+// index.
+func DiffKey(path, field string) DiffOption {
+	return diffOptionFunc(func(d *differ) {
+		d.keys = append(d.keys, sequenceKey{
+			pattern: path,
+			field:   field,
+		})
+	})
+}
+
+type sequenceKey struct {
+	pattern string
+	field   string
+}
+
+type differ struct {
+	keys []sequenceKey
+	// fields maps the first element of each sequence matched by a DiffKey
+	// pattern to the field its elements are compared by. Since paths within
+	// keyed sequences don't use indexes, sequences are identified by their
+	// contents rather than their paths.
+	fields  map[*interface{}]string
+	changes []Change
+}
+
+// Diff compares the merged configuration at key in two providers and returns
+// the differences, treating a as the older configuration and b as the newer.
+// Mappings are compared key by key and sequences element by element, so the
+// result reflects semantic rather than textual changes. Changes are ordered
+// by path, with mapping keys sorted lexically and sequence elements in index
+// order, so the result is deterministic.
+func Diff(a, b Provider, key string, opts ...DiffOption) []Change {
+	d := &differ{}
+	for _, o := range opts {
+		o.apply(d)
+	}
+	old, new := a.Get(key), b.Get(key)
+	oldFound, newFound := old.HasValue(), new.HasValue()
+	switch {
+	case !oldFound && !newFound:
+	case !oldFound:
+		d.add(Change{Path: []string{}, Kind: Added, New: new.Value()})
+	case !newFound:
+		d.add(Change{Path: []string{}, Kind: Removed, Old: old.Value()})
+	default:
+		o, n := old.Value(), new.Value()
+		d.index(old, o)
+		d.index(new, n)
+		d.diff([]string{}, o, n)
+	}
+	return d.changes
+}
+
+// index records the sequences within contents, the unmarshalled form of v,
+// that match DiffKey patterns. Earlier options take precedence.
+func (d *differ) index(v Value, contents interface{}) {
+	if len(d.keys) == 0 {
+		return
+	}
+	if d.fields == nil {
+		d.fields = make(map[*interface{}]string)
+	}
+	for _, k := range d.keys {
+		field := k.field
+		query(contents, k.pattern, v.provider.normalize, func(_ []string, match interface{}) {
+			seq, ok := match.([]interface{})
+			if !ok || len(seq) == 0 {
+				return
+			}
+			if _, ok := d.fields[&seq[0]]; !ok {
+				d.fields[&seq[0]] = field
+			}
+		})
+	}
+}
+
+func (d *differ) add(c Change) {
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) diff(path []string, old, new interface{}) {
+	switch {
+	case merge.IsMapping(old) && merge.IsMapping(new):
+		d.diffMappings(path, old.(map[interface{}]interface{}), new.(map[interface{}]interface{}))
+	case merge.IsSequence(old) && merge.IsSequence(new):
+		d.diffSequences(path, old.([]interface{}), new.([]interface{}))
+	case !reflect.DeepEqual(old, new):
+		d.add(Change{Path: path, Kind: Modified, Old: old, New: new})
+	}
+}
+
+func (d *differ) diffMappings(path []string, old, new map[interface{}]interface{}) {
+	keys := make([]interface{}, 0, len(old)+len(new))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sortKeys(keys)
+	for _, k := range keys {
+		o, inOld := old[k]
+		n, inNew := new[k]
+		d.diffEntries(extend(path, fmt.Sprint(k)), o, inOld, n, inNew)
+	}
+}
+
+// sortKeys sorts YAML mapping keys by their string forms. Since keys needn't
+// be strings, it breaks ties by type to keep keys like 2 and "2" in a stable
+// order.
+func sortKeys(keys []interface{}) {
+	sort.Slice(keys, func(i, j int) bool {
+		si, sj := fmt.Sprint(keys[i]), fmt.Sprint(keys[j])
+		if si != sj {
+			return si < sj
+		}
+		return fmt.Sprintf("%T", keys[i]) < fmt.Sprintf("%T", keys[j])
+	})
+}
+
+func (d *differ) diffSequences(path []string, old, new []interface{}) {
+	if field, ok := d.keyFor(old, new); ok {
+		oldByKey, okOld := indexByField(old, field)
+		newByKey, okNew := indexByField(new, field)
+		if okOld && okNew {
+			keys := make([]string, 0, len(oldByKey)+len(newByKey))
+			for k := range oldByKey {
+				keys = append(keys, k)
+			}
+			for k := range newByKey {
+				if _, ok := oldByKey[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				o, inOld := oldByKey[k]
+				n, inNew := newByKey[k]
+				d.diffEntries(extend(path, field+"="+k), o, inOld, n, inNew)
+			}
+			return
+		}
+	}
+
+	length := len(old)
+	if len(new) > length {
+		length = len(new)
+	}
+	for i := 0; i < length; i++ {
+		var o, n interface{}
+		inOld, inNew := i < len(old), i < len(new)
+		if inOld {
+			o = old[i]
+		}
+		if inNew {
+			n = new[i]
+		}
+		d.diffEntries(extend(path, strconv.Itoa(i)), o, inOld, n, inNew)
+	}
+}
+
+func (d *differ) diffEntries(path []string, old interface{}, inOld bool, new interface{}, inNew bool) {
+	switch {
+	case inOld && inNew:
+		d.diff(path, old, new)
+	case inOld:
+		d.add(Change{Path: path, Kind: Removed, Old: old})
+	case inNew:
+		d.add(Change{Path: path, Kind: Added, New: new})
+	}
+}
+
+// keyFor returns the field that elements of a pair of sequences are compared
+// by, if either sequence matched a DiffKey pattern.
+func (d *differ) keyFor(old, new []interface{}) (string, bool) {
+	for _, seq := range [][]interface{}{old, new} {
+		if len(seq) == 0 {
+			continue
+		}
+		if field, ok := d.fields[&seq[0]]; ok {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+// indexByField indexes the elements of a sequence by the string form of
+// their field entry. It fails if any element isn't a mapping with a unique,
+// scalar value for the field.
+func indexByField(seq []interface{}, field string) (map[string]interface{}, bool) {
+	byKey := make(map[string]interface{}, len(seq))
+	for _, elem := range seq {
+		m, ok := elem.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+		v, ok := m[field]
+		if !ok || !merge.IsScalar(v) {
+			return nil, false
+		}
+		k := fmt.Sprint(v)
+		if _, dup := byKey[k]; dup {
+			return nil, false
+		}
+		byKey[k] = elem
+	}
+	return byKey, true
+}
+
+// extend returns a copy of path with segment appended, so that sibling
+// changes never share a backing array.
+func extend(path []string, segment string) []string {
+	extended := make([]string, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, segment)
+}
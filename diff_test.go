@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustYAML(t testing.TB, s string) *YAML {
+	p, err := NewYAML(Source(strings.NewReader(s)))
+	require.NoError(t, err, "couldn't construct provider")
+	return p
+}
+
+func TestDiff(t *testing.T) {
+	old := mustYAML(t, `
+name: api
+removed: true
+limits: {rps: 10, burst: 20}
+hosts: [a, b, c]
+kind: {nested: true}
+`)
+	new := mustYAML(t, `
+# Comments and ordering don't matter.
+limits: {burst: 20, rps: 15}
+name: api
+hosts: [a, x]
+kind: scalar
+added: [1]
+`)
+
+	assert.Equal(t, []Change{
+		{Path: []string{"added"}, Kind: Added, New: []interface{}{1}},
+		{Path: []string{"hosts", "1"}, Kind: Modified, Old: "b", New: "x"},
+		{Path: []string{"hosts", "2"}, Kind: Removed, Old: "c"},
+		{Path: []string{"kind"}, Kind: Modified, Old: map[interface{}]interface{}{"nested": true}, New: "scalar"},
+		{Path: []string{"limits", "rps"}, Kind: Modified, Old: 10, New: 15},
+		{Path: []string{"removed"}, Kind: Removed, Old: true},
+	}, Diff(old, new, Root), "unexpected changes")
+
+	assert.Equal(t, []Change{
+		{Path: []string{"rps"}, Kind: Modified, Old: 10, New: 15},
+	}, Diff(old, new, "limits"), "unexpected changes in subtree")
+
+	assert.Empty(t, Diff(old, old, Root), "expected no changes comparing a provider to itself")
+}
+
+func TestDiffMissing(t *testing.T) {
+	p := mustYAML(t, "foo: bar")
+	assert.Empty(t, Diff(p, p, "not_there"), "expected no changes when both are missing")
+	assert.Equal(t, []Change{
+		{Path: []string{}, Kind: Added, New: "bar"},
+	}, Diff(NopProvider{}, p, "foo"), "expected addition")
+	assert.Equal(t, []Change{
+		{Path: []string{}, Kind: Removed, Old: "bar"},
+	}, Diff(p, NopProvider{}, "foo"), "expected removal")
+}
+
+func TestDiffKey(t *testing.T) {
+	old := mustYAML(t, `
+services:
+  api:
+    backends: [{name: a, port: 1}, {name: b, port: 2}]
+`)
+	new := mustYAML(t, `
+services:
+  api:
+    backends: [{name: c, port: 3}, {name: b, port: 20}]
+`)
+
+	assert.Equal(t, []Change{
+		{Path: []string{"services", "api", "backends", "name=a"}, Kind: Removed, Old: map[interface{}]interface{}{"name": "a", "port": 1}},
+		{Path: []string{"services", "api", "backends", "name=b", "port"}, Kind: Modified, Old: 2, New: 20},
+		{Path: []string{"services", "api", "backends", "name=c"}, Kind: Added, New: map[interface{}]interface{}{"name": "c", "port": 3}},
+	}, Diff(old, new, Root, DiffKey("services.*.backends", "name")), "unexpected keyed changes")
+
+	t.Run("fallback to index", func(t *testing.T) {
+		changes := Diff(old, new, Root, DiffKey("services.*.backends", "missing"))
+		require.Len(t, changes, 3, "unexpected number of changes")
+		assert.Equal(t, []string{"services", "api", "backends", "0", "name"}, changes[0].Path, "expected index-based paths")
+	})
+}
+
+func TestDiffMixedKeys(t *testing.T) {
+	old := mustYAML(t, `{2: int, "2": str}`)
+	new := mustYAML(t, `{2: new int, "2": new str}`)
+	changes := Diff(old, new, Root)
+	require.Len(t, changes, 2, "expected a change for each key")
+	assert.Equal(t, "modified 2: int -> new int", changes[0].String(), "unexpected order")
+	assert.Equal(t, "modified 2: str -> new str", changes[1].String(), "unexpected order")
+}
+
+func TestChangeString(t *testing.T) {
+	assert.Equal(t, "added foo.bar: 1", Change{Path: []string{"foo", "bar"}, Kind: Added, New: 1}.String())
+	assert.Equal(t, "removed foo: 1", Change{Path: []string{"foo"}, Kind: Removed, Old: 1}.String())
+	assert.Equal(t, "modified foo: 1 -> 2", Change{Path: []string{"foo"}, Kind: Modified, Old: 1, New: 2}.String())
+	assert.Equal(t, "ChangeKind(42)", ChangeKind(42).String())
+}
@@ -89,6 +89,36 @@
 //	# merged output
 //	foo: ~
 //
+// A source may contain several YAML documents separated by "---" lines. Each
+// document is merged in turn, exactly as if it were a separate source, so
+// later documents override earlier ones. Documents containing only comments
+// are ignored.
+//
+// Anchors defined in one source (or document) may be used by any later one.
+// An alias copies the anchored value as written, before merging, so
+// overriding part of the anchored value in a later source doesn't affect
+// aliases to it:
+//
+//	# base.yaml
+//	defaults: &defaults
+//	  timeout: 1s
+//
+//	# override.yaml
+//	defaults:
+//	  timeout: 2s
+//	service:
+//	  <<: *defaults
+//
+//	# merged output
+//	defaults:
+//	  timeout: 2s
+//	service:
+//	  timeout: 1s
+//
+// If an anchor is defined more than once, aliases refer to the most recent
+// definition. Aliases to undefined anchors are an error, even in permissive
+// mode.
+//
 // # Strict Unmarshalling
 //
 // By default, the NewYAML constructor enables gopkg.in/yaml.v2's strict
@@ -99,9 +129,55 @@
 // encounters incompatible data types. This behavior can be disabled with the
 // Permissive option.
 //
+// When Populate finds unused keys, it reports each one's full path and the
+// source and line that defined it, along with the most similar field names
+// in the target struct:
+//
+//	server.tiemout: field tiemout not found in type app.Server; did you mean timeout? (from base.yaml:3)
+//
 // To maintain backward compatibility, all other constructors default to
 // permissive unmarshalling.
 //
+// # Default Values
+//
+// The simplest way to supply defaults is to set them on a struct before
+// calling Populate. Defaults for elements of slices and values of maps can't
+// be set that way, so Populate also supports a "default" struct tag. The
+// tag's contents are parsed as YAML and used whenever the field's key is
+// absent from the configuration:
+//
+//	type Backend struct {
+//	  Host    string
+//	  Timeout time.Duration `default:"1s"`
+//	  Tags    []string      `default:"[primary]"`
+//	}
+//
+//	type Config struct {
+//	  Backends []Backend
+//	  Limits   map[string]int `default:"{rps: 100}"`
+//	}
+//
+// Tag defaults apply within nested structs, slice and array elements, and
+// map values, even when the value being populated is missing entirely, but
+// absent pointer fields are left nil. Explicit nulls in the configuration
+// aren't absent, so they don't trigger defaults. Since tag defaults are part
+// of the configuration being unmarshalled, they take precedence over values
+// already set on the struct: if a field's key is absent, Populate replaces
+// whatever the field held with its tag default.
+//
+// # Renamed Keys
+//
+// When renaming a key, keep accepting the old name for a while with a
+// "config" struct tag:
+//
+//	type Config struct {
+//	  Timeout time.Duration `config:"alias=request_timeout,deprecated"`
+//	}
+//
+// Populate treats the alias as the field's key, and it fails if both are set
+// to different values. Use the OnDeprecation option to log uses of
+// deprecated keys.
+//
 // # Quote Strings
 //
 // YAML allows strings to appear quoted or unquoted, so these two lines are
@@ -124,6 +200,14 @@
 //	foo: true # after merge
 //
 // Quoting special-cased strings prevents this surprising behavior.
+// Alternatively, the YAMLv3 option parses sources with gopkg.in/yaml.v3,
+// which follows YAML 1.2: only true and false are Booleans, so yes stays a
+// string.
+//
+// To find special-cased strings before they cause surprises, use the Lint
+// option, which reports unquoted nulls and octal-looking integers and, unless
+// the YAMLv3 option is also used, unquoted Booleans other than true and
+// false.
 //
 // # Deprecated APIs
 //
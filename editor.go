@@ -0,0 +1,648 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"unicode/utf8"
+
+	"go.uber.org/config/internal/merge"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// An Editor changes individual values in a YAML file, leaving the rest of
+// the file as it was. It's intended for tools that update checked-in
+// configuration, such as a deploy script bumping a replica count:
+//
+//	ed, err := config.LoadEditor("production.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	if err := ed.Set("service.replicas", 5); err != nil {
+//		return err
+//	}
+//	return ed.Save()
+//
+// Keys use the same syntax as Get. Where possible, edits are made in place:
+// replacing a scalar changes only the scalar (keeping its quoting style),
+// and adding, replacing, or deleting a key in a block mapping changes only
+// that key's lines. Other edits re-encode the file, which keeps comments and
+// key order but may change indentation and quoting.
+//
+// Editors edit a single file, without expanding environment variables. To
+// preview an edit's effect on the merged configuration, construct a provider
+// with the Source option in place of the edited file.
+type Editor struct {
+	path      string
+	mode      os.FileMode
+	src       []byte
+	normalize func(string) string // see NormalizeKeys
+}
+
+// LoadEditor reads a YAML file for editing. Keys are resolved as Get
+// resolves them, so a segment like 1 or true also matches a non-string
+// mapping key. Pass NormalizeKeys to resolve differently-spelled keys too;
+// other options are ignored.
+func LoadEditor(path string, opts ...YAMLOption) (*Editor, error) {
+	cfg := config{}
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseDocuments(src); err != nil {
+		return nil, fmt.Errorf("couldn't parse %s: %v", path, err)
+	}
+	return &Editor{path: path, mode: info.Mode().Perm(), src: src, normalize: cfg.normalize}, nil
+}
+
+// Set sets the value at a key, creating any missing mappings along the way.
+// Sequence indexes must refer to existing elements, though they may be
+// negative, as with Get. Keys supplied by merge keys ("<<") are overridden
+// rather than changed, and keys that go through aliases can't be set.
+//
+// In files with several documents, Set changes the last document that
+// defines the key, or the last document if none do.
+func (e *Editor) Set(key string, value interface{}) error {
+	var n yamlv3.Node
+	if err := n.Encode(value); err != nil {
+		return fmt.Errorf("couldn't encode value for key %q: %v", key, err)
+	}
+	return e.edit(key, &n)
+}
+
+// Delete removes the value at a key from the last document that defines it.
+// It's an error if no document defines the key.
+func (e *Editor) Delete(key string) error {
+	if editPath(key) == nil {
+		return errors.New("can't delete the root of a file")
+	}
+	return e.edit(key, nil)
+}
+
+// Bytes returns the edited file's contents.
+func (e *Editor) Bytes() []byte {
+	return append([]byte(nil), e.src...)
+}
+
+// Source returns a YAMLOption that adds the edited file as a source of
+// configuration, named for the file. It reflects the edits made so far, but
+// not later ones.
+func (e *Editor) Source() YAMLOption {
+	return appendSources([][]byte{e.Bytes()}, []string{e.path})
+}
+
+// Save writes the edited file, keeping its permissions. It writes to a
+// temporary file in the same directory and then renames it, so the file is
+// never left partially written.
+func (e *Editor) Save() (err error) {
+	f, err := ioutil.TempFile(filepath.Dir(e.path), "."+filepath.Base(e.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if err := f.Chmod(e.mode); err != nil {
+		return err
+	}
+	if _, err := f.Write(e.src); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), e.path)
+}
+
+func editPath(key string) []string {
+	path := splitKey(key)
+	if len(path) == 1 && path[0] == Root {
+		return nil
+	}
+	return path
+}
+
+// edit sets the value at a key, or deletes it if value is nil.
+func (e *Editor) edit(key string, value *yamlv3.Node) error {
+	docs, err := parseDocuments(e.src)
+	if err != nil {
+		return err
+	}
+	path := editPath(key)
+
+	var doc *yamlv3.Node
+	for i := len(docs) - 1; i >= 0 && doc == nil; i-- {
+		if _, ok := findNode(docs[i], path, e.normalize); ok {
+			doc = docs[i]
+		}
+	}
+	if doc == nil {
+		if value == nil {
+			return fmt.Errorf("no configuration at key %q", key)
+		}
+		if len(docs) == 0 {
+			docs = append(docs, &yamlv3.Node{Kind: yamlv3.DocumentNode})
+		}
+		doc = docs[len(docs)-1]
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yamlv3.Node{{Kind: yamlv3.ScalarNode, Tag: "!!null"}}
+	}
+
+	t := newSourceText(e.src, e.normalize)
+	change, err := t.change(doc, path, value)
+	if err != nil {
+		return fmt.Errorf("can't edit key %q: %v", key, err)
+	}
+	// Only keep an in-place edit if it has the same effect as editing the
+	// parsed document.
+	if change != nil {
+		if edited := change.apply(e.src); sameDocuments(edited, docs) {
+			e.src = edited
+			return nil
+		}
+	}
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(t.indentUnit())
+	for _, d := range docs {
+		if err := enc.Encode(d); err != nil {
+			return fmt.Errorf("couldn't encode edited YAML: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("couldn't encode edited YAML: %v", err)
+	}
+	e.src = buf.Bytes()
+	return nil
+}
+
+func parseDocuments(src []byte) ([]*yamlv3.Node, error) {
+	var docs []*yamlv3.Node
+	dec := yamlv3.NewDecoder(bytes.NewReader(src))
+	for {
+		var n yamlv3.Node
+		if err := dec.Decode(&n); err == io.EOF {
+			return docs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		docs = append(docs, &n)
+	}
+}
+
+// sameDocuments reports whether src holds the same data as docs.
+func sameDocuments(src []byte, docs []*yamlv3.Node) bool {
+	parsed, err := parseDocuments(src)
+	if err != nil || len(parsed) != len(docs) {
+		return false
+	}
+	for i := range docs {
+		var want, got interface{}
+		if docs[i].Decode(&want) != nil || parsed[i].Decode(&got) != nil {
+			return false
+		}
+		if !reflect.DeepEqual(want, got) {
+			return false
+		}
+	}
+	return true
+}
+
+// findNode returns the node at a path in a document, without following
+// aliases or merge keys.
+func findNode(doc *yamlv3.Node, path []string, normalize func(string) string) (*yamlv3.Node, bool) {
+	if len(doc.Content) == 0 {
+		return nil, false
+	}
+	n := doc.Content[0]
+	for _, segment := range path {
+		i, ok := childIndex(n, segment, normalize)
+		if !ok {
+			return nil, false
+		}
+		n = n.Content[i]
+	}
+	return n, true
+}
+
+// childIndex returns the index in n.Content of the value at segment. Mapping
+// keys are resolved with the same rules as Get (see resolveKey).
+func childIndex(n *yamlv3.Node, segment string, normalize func(string) string) (int, bool) {
+	switch n.Kind {
+	case yamlv3.MappingNode:
+		keys := make(map[interface{}]int, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k := n.Content[i]
+			if k.Kind != yamlv3.ScalarNode {
+				continue
+			}
+			var key interface{}
+			if err := k.Decode(&key); err != nil || !merge.IsScalar(key) {
+				continue
+			}
+			if _, ok := keys[key]; !ok {
+				keys[key] = i + 1
+			}
+		}
+		if key, ok := resolveKey(keys, segment, normalize); ok {
+			return keys[key], true
+		}
+	case yamlv3.SequenceNode:
+		return sequenceIndex(segment, len(n.Content))
+	}
+	return 0, false
+}
+
+// A textEdit replaces src[start:end] with text.
+type textEdit struct {
+	start, end int
+	text       []byte
+}
+
+func (t *textEdit) apply(src []byte) []byte {
+	edited := make([]byte, 0, len(src)-(t.end-t.start)+len(t.text))
+	edited = append(edited, src[:t.start]...)
+	edited = append(edited, t.text...)
+	return append(edited, src[t.end:]...)
+}
+
+// sourceText is a YAML source split into lines, which are numbered from one
+// like node positions.
+type sourceText struct {
+	src       []byte
+	lines     [][]byte            // including newlines
+	starts    []int               // offset of each line in src
+	normalize func(string) string // see NormalizeKeys
+}
+
+func newSourceText(src []byte, normalize func(string) string) *sourceText {
+	t := &sourceText{src: src, normalize: normalize}
+	offset := 0
+	for _, line := range bytes.SplitAfter(src, []byte("\n")) {
+		if len(line) == 0 {
+			break
+		}
+		t.lines = append(t.lines, line)
+		t.starts = append(t.starts, offset)
+		offset += len(line)
+	}
+	return t
+}
+
+// offset converts a line and column to an offset in src.
+func (t *sourceText) offset(line, column int) int {
+	off := 0
+	l := t.lines[line-1]
+	for c := 1; c < column && off < len(l); c++ {
+		_, size := utf8.DecodeRune(l[off:])
+		off += size
+	}
+	return t.starts[line-1] + off
+}
+
+// linesEdit replaces lines first through last (inclusive) with text. To
+// insert text before first, pass first-1 as last.
+func (t *sourceText) linesEdit(first, last int, text []byte) *textEdit {
+	start, end := len(t.src), len(t.src)
+	if first <= len(t.lines) {
+		start = t.starts[first-1]
+	}
+	if last < len(t.lines) {
+		end = t.starts[last]
+	}
+	if start == len(t.src) && len(t.src) > 0 && t.src[len(t.src)-1] != '\n' {
+		// Appending to a file without a trailing newline.
+		text = append([]byte("\n"), text...)
+	}
+	return &textEdit{start: start, end: end, text: text}
+}
+
+// indentation returns the indentation of a line, and whether the line holds
+// content (rather than being blank or a comment).
+func (t *sourceText) indentation(line int) (int, bool) {
+	l := t.lines[line-1]
+	trimmed := bytes.TrimLeft(l, " ")
+	content := bytes.TrimSpace(trimmed)
+	return len(l) - len(trimmed), len(content) > 0 && content[0] != '#'
+}
+
+// indentUnit guesses the number of spaces the source uses for each level of
+// indentation, defaulting to two.
+func (t *sourceText) indentUnit() int {
+	unit := 0
+	for i := range t.lines {
+		if n, ok := t.indentation(i + 1); ok && n > 0 && (unit == 0 || n < unit) {
+			unit = n
+		}
+	}
+	if unit < 2 || unit > 9 {
+		// Outside the range yaml.v3 supports.
+		return 2
+	}
+	return unit
+}
+
+// entryLines returns the lines holding the i'th pair in a block mapping,
+// excluding any trailing blank lines and comments. It returns false if
+// other content shares the key's line.
+func (t *sourceText) entryLines(m *yamlv3.Node, i int) (int, int, bool) {
+	k := m.Content[i]
+	if k.Line < 1 || k.Line > len(t.lines) {
+		return 0, 0, false
+	}
+	if before := t.src[t.starts[k.Line-1]:t.offset(k.Line, k.Column)]; len(bytes.TrimSpace(before)) > 0 {
+		return 0, 0, false
+	}
+	last := len(t.lines)
+	if i+2 < len(m.Content) {
+		last = m.Content[i+2].Line - 1
+	} else {
+		for l := k.Line + 1; l <= len(t.lines); l++ {
+			if n, ok := t.indentation(l); ok && n < k.Column {
+				last = l - 1
+				break
+			}
+		}
+	}
+	for last > k.Line {
+		if _, ok := t.indentation(last); ok {
+			break
+		}
+		last--
+	}
+	return k.Line, last, true
+}
+
+// change sets or deletes the value at a path in a document, returning an
+// equivalent edit to the source text if one is available.
+func (t *sourceText) change(doc *yamlv3.Node, path []string, value *yamlv3.Node) (*textEdit, error) {
+	if len(path) == 0 {
+		doc.Content[0] = value
+		return nil, nil
+	}
+	parent := doc.Content[0]
+	for i, segment := range path[:len(path)-1] {
+		if parent.Kind == yamlv3.AliasNode {
+			return nil, fmt.Errorf("%q is an alias", joinPath(path[:i]))
+		}
+		j, ok := childIndex(parent, segment, t.normalize)
+		if !ok {
+			if value == nil {
+				return nil, errors.New("not found")
+			}
+			// Create the rest of the path.
+			for k := len(path) - 1; k > i; k-- {
+				value = &yamlv3.Node{
+					Kind:    yamlv3.MappingNode,
+					Tag:     "!!map",
+					Content: []*yamlv3.Node{keyNode(path[k]), value},
+				}
+			}
+			path = path[:i+1]
+			break
+		}
+		parent = parent.Content[j]
+	}
+	segment := path[len(path)-1]
+	if parent.Kind == yamlv3.AliasNode {
+		return nil, fmt.Errorf("%q is an alias", joinPath(path[:len(path)-1]))
+	}
+
+	switch parent.Kind {
+	case yamlv3.MappingNode:
+		j, ok := childIndex(parent, segment, t.normalize)
+		switch {
+		case ok && value == nil:
+			edit := t.deleteEntry(parent, j-1)
+			parent.Content = append(parent.Content[:j-1], parent.Content[j+1:]...)
+			return edit, nil
+		case ok:
+			edit := t.replaceEntry(parent, j-1, value)
+			parent.Content[j] = value
+			return edit, nil
+		case value == nil:
+			return nil, errors.New("not found")
+		default:
+			key := keyNode(segment)
+			edit := t.appendEntry(parent, key, value)
+			parent.Content = append(parent.Content, key, value)
+			return edit, nil
+		}
+	case yamlv3.SequenceNode:
+		j, ok := sequenceIndex(segment, len(parent.Content))
+		if !ok {
+			return nil, fmt.Errorf("index %s out of range for sequence of length %d", segment, len(parent.Content))
+		}
+		if value == nil {
+			parent.Content = append(parent.Content[:j], parent.Content[j+1:]...)
+			return nil, nil
+		}
+		edit := t.replaceScalar(parent.Content[j], value)
+		parent.Content[j] = value
+		return edit, nil
+	}
+	if value != nil && parent.Kind == yamlv3.ScalarNode && parent.ShortTag() == "!!null" {
+		// Replace the null with a mapping.
+		*parent = yamlv3.Node{
+			Kind:        yamlv3.MappingNode,
+			Tag:         "!!map",
+			Content:     []*yamlv3.Node{keyNode(segment), value},
+			HeadComment: parent.HeadComment,
+			LineComment: parent.LineComment,
+			FootComment: parent.FootComment,
+		}
+		return nil, nil
+	}
+	if value == nil {
+		return nil, errors.New("not found")
+	}
+	return nil, fmt.Errorf("%q isn't a mapping or sequence", joinPath(path[:len(path)-1]))
+}
+
+func keyNode(segment string) *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: segment}
+}
+
+func isBlockMapping(m *yamlv3.Node) bool {
+	return m.Kind == yamlv3.MappingNode && m.Style&yamlv3.FlowStyle == 0 && len(m.Content) > 0
+}
+
+// replaceEntry replaces the value of the i'th pair in a mapping.
+func (t *sourceText) replaceEntry(m *yamlv3.Node, i int, value *yamlv3.Node) *textEdit {
+	if edit := t.replaceScalar(m.Content[i+1], value); edit != nil {
+		return edit
+	}
+	if !isBlockMapping(m) {
+		return nil
+	}
+	first, last, ok := t.entryLines(m, i)
+	if !ok {
+		return nil
+	}
+	key := *m.Content[i]
+	key.HeadComment, key.LineComment, key.FootComment = "", "", ""
+	text, ok := t.render(&key, value, m.Content[i].Column-1)
+	if !ok {
+		return nil
+	}
+	return t.linesEdit(first, last, text)
+}
+
+// deleteEntry removes the i'th pair in a mapping, along with the comments
+// directly above it.
+func (t *sourceText) deleteEntry(m *yamlv3.Node, i int) *textEdit {
+	if !isBlockMapping(m) {
+		return nil
+	}
+	first, last, ok := t.entryLines(m, i)
+	if !ok {
+		return nil
+	}
+	indent := m.Content[i].Column - 1
+	for first > 1 {
+		n, content := t.indentation(first - 1)
+		if content || n != indent || len(bytes.TrimSpace(t.lines[first-2])) == 0 {
+			break
+		}
+		first--
+	}
+	return t.linesEdit(first, last, nil)
+}
+
+// appendEntry adds a pair after the last one in a mapping.
+func (t *sourceText) appendEntry(m *yamlv3.Node, key, value *yamlv3.Node) *textEdit {
+	if !isBlockMapping(m) {
+		return nil
+	}
+	_, last, ok := t.entryLines(m, len(m.Content)-2)
+	if !ok {
+		return nil
+	}
+	text, ok := t.render(key, value, m.Content[0].Column-1)
+	if !ok {
+		return nil
+	}
+	return t.linesEdit(last+1, last, text)
+}
+
+// render encodes a key-value pair, indented to a column.
+func (t *sourceText) render(key, value *yamlv3.Node, indent int) ([]byte, bool) {
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(t.indentUnit())
+	pair := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map", Content: []*yamlv3.Node{key, value}}
+	if err := enc.Encode(pair); err != nil || enc.Close() != nil {
+		return nil, false
+	}
+	var text []byte
+	for _, line := range bytes.SplitAfter(buf.Bytes(), []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			text = append(text, bytes.Repeat([]byte(" "), indent)...)
+		}
+		text = append(text, line...)
+	}
+	return text, true
+}
+
+// replaceScalar replaces a single-line scalar with another, keeping the old
+// scalar's quoting style if both are strings.
+func (t *sourceText) replaceScalar(old, value *yamlv3.Node) *textEdit {
+	if old.Kind != yamlv3.ScalarNode || value.Kind != yamlv3.ScalarNode || old.Anchor != "" {
+		return nil
+	}
+	if old.Style&(yamlv3.TaggedStyle|yamlv3.LiteralStyle|yamlv3.FoldedStyle) != 0 {
+		return nil
+	}
+	if old.Line < 1 || old.Line > len(t.lines) {
+		return nil
+	}
+	start := t.offset(old.Line, old.Column)
+	end, ok := scalarEnd(t.src[start:t.starts[old.Line-1]+len(t.lines[old.Line-1])], old)
+	if !ok {
+		return nil
+	}
+
+	if old.ShortTag() == "!!str" && value.ShortTag() == "!!str" {
+		value.Style = old.Style & (yamlv3.SingleQuotedStyle | yamlv3.DoubleQuotedStyle)
+	}
+	text, err := yamlv3.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	text = bytes.TrimSuffix(text, []byte("\n"))
+	if bytes.Contains(text, []byte("\n")) {
+		return nil
+	}
+	return &textEdit{start: start, end: start + end, text: text}
+}
+
+// scalarEnd returns the length of a single-line scalar at the start of rest,
+// which holds the remainder of the scalar's line.
+func scalarEnd(rest []byte, n *yamlv3.Node) (int, bool) {
+	switch {
+	case n.Style&yamlv3.SingleQuotedStyle != 0:
+		for i := 1; i < len(rest); i++ {
+			if rest[i] != '\'' {
+				continue
+			}
+			if i+1 < len(rest) && rest[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1, rest[0] == '\''
+		}
+	case n.Style&yamlv3.DoubleQuotedStyle != 0:
+		for i := 1; i < len(rest); i++ {
+			switch rest[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1, rest[0] == '"'
+			}
+		}
+	default:
+		if bytes.HasPrefix(rest, []byte(n.Value)) {
+			return len(n.Value), true
+		}
+	}
+	return 0, false
+}
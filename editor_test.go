@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _editorSource = `# Production overrides.
+service:
+  name: "api"   # quoted on purpose
+  replicas: 3 # bump during launches
+  # Limits apply per replica.
+  limits:
+    cpu: 2
+    memory: 1Gi
+
+  hosts: [a.example.com, b.example.com]
+tags:
+  - blue
+  - green
+`
+
+func newEditor(t testing.TB, src string) *Editor {
+	path := filepath.Join(t.TempDir(), "production.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(src), 0600), "couldn't write file")
+	ed, err := LoadEditor(path)
+	require.NoError(t, err, "couldn't load editor")
+	return ed
+}
+
+func TestEditorInPlace(t *testing.T) {
+	tests := []struct {
+		desc string
+		edit func(*Editor) error
+		want string
+	}{
+		{
+			desc: "scalar",
+			edit: func(ed *Editor) error { return ed.Set("service.replicas", 5) },
+			want: "  replicas: 5 # bump during launches\n",
+		},
+		{
+			desc: "quoted scalar",
+			edit: func(ed *Editor) error { return ed.Set("service.name", "web") },
+			want: "  name: \"web\"   # quoted on purpose\n",
+		},
+		{
+			desc: "flow sequence element",
+			edit: func(ed *Editor) error { return ed.Set(`service.hosts[-1]`, "c.example.com") },
+			want: "  hosts: [a.example.com, c.example.com]\n",
+		},
+		{
+			desc: "new key",
+			edit: func(ed *Editor) error { return ed.Set("service.limits.disk", "10Gi") },
+			want: "    memory: 1Gi\n    disk: 10Gi\n\n",
+		},
+		{
+			desc: "new mappings",
+			edit: func(ed *Editor) error { return ed.Set("service.probe.http.path", "/health") },
+			want: "  hosts: [a.example.com, b.example.com]\n  probe:\n    http:\n      path: /health\ntags:\n",
+		},
+		{
+			desc: "mapping",
+			edit: func(ed *Editor) error {
+				return ed.Set("service.limits", map[string]int{"cpu": 4})
+			},
+			want: "  # Limits apply per replica.\n  limits:\n    cpu: 4\n\n  hosts:",
+		},
+		{
+			desc: "delete",
+			edit: func(ed *Editor) error { return ed.Delete("service.limits") },
+			want: "  replicas: 3 # bump during launches\n\n  hosts:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ed := newEditor(t, _editorSource)
+			require.NoError(t, tt.edit(ed), "edit failed")
+			got := string(ed.Bytes())
+			assert.Contains(t, got, tt.want, "unexpected edit")
+			assert.Contains(t, got, "# Production overrides.\n", "expected comments to be kept")
+			assert.Contains(t, got, "tags:\n  - blue\n  - green\n", "expected formatting to be kept")
+		})
+	}
+}
+
+func TestEditorReencode(t *testing.T) {
+	ed := newEditor(t, "# Flow style.\nservice: {name: api, replicas: 3}\n")
+	require.NoError(t, ed.Set("service.replicas", 4), "set failed")
+	assert.Equal(t, "# Flow style.\nservice: {name: api, replicas: 4}\n", string(ed.Bytes()), "expected an in-place edit")
+
+	require.NoError(t, ed.Set("service.region", "us-east"), "set failed")
+	assert.Equal(t,
+		"# Flow style.\nservice: {name: api, replicas: 4, region: us-east}\n",
+		string(ed.Bytes()),
+		"expected a re-encoded document with comments",
+	)
+
+	require.NoError(t, ed.Set(Root, map[string]int{"a": 1}), "set failed")
+	assert.Equal(t, "a: 1\n", string(ed.Bytes()), "unexpected root replacement")
+}
+
+func TestEditorDocuments(t *testing.T) {
+	ed := newEditor(t, "a: 1\nb: 1\n---\na: 2\n")
+
+	require.NoError(t, ed.Set("b", 3), "set failed")
+	require.NoError(t, ed.Set("a", 4), "set failed")
+	require.NoError(t, ed.Set("c", 5), "set failed")
+	assert.Equal(t, "a: 1\nb: 3\n---\na: 4\nc: 5\n", string(ed.Bytes()), "unexpected documents")
+}
+
+func TestEditorKeys(t *testing.T) {
+	const src = "MaxConns: 1\n1.5: a\ntrue: b\n'2': c\n"
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(src), 0600), "couldn't write file")
+	ed, err := LoadEditor(path, NormalizeKeys())
+	require.NoError(t, err, "couldn't load editor")
+
+	require.NoError(t, ed.Set("max_conns", 2), "set failed")
+	require.NoError(t, ed.Set(`["1.5"]`, "x"), "set failed")
+	require.NoError(t, ed.Set("true", "y"), "set failed")
+	require.NoError(t, ed.Delete("2"), "delete failed")
+	assert.Equal(t, "MaxConns: 2\n1.5: x\ntrue: y\n", string(ed.Bytes()), "expected keys to resolve as they do for Get")
+
+	ed, err = LoadEditor(path)
+	require.NoError(t, err, "couldn't load editor")
+	require.NoError(t, ed.Set("max_conns", 2), "set failed")
+	assert.Equal(t, src+"max_conns: 2\n", string(ed.Bytes()), "expected keys to be spelled exactly without NormalizeKeys")
+}
+
+func TestEditorErrors(t *testing.T) {
+	const src = "base: &base {a: 1}\nservice:\n  <<: *base\nalias: *base\nlist: [1]\nname: api\n"
+	ed := newEditor(t, src)
+
+	tests := []struct {
+		desc string
+		err  error
+		want string
+	}{
+		{"missing key", ed.Delete("service.a"), `no configuration at key "service.a"`},
+		{"missing parent", ed.Delete("missing.a"), `no configuration at key "missing.a"`},
+		{"root", ed.Delete(Root), "can't delete the root of a file"},
+		{"alias", ed.Set("alias.a", 2), `can't edit key "alias.a": "alias" is an alias`},
+		{"index", ed.Set("list.1", 2), `can't edit key "list.1": index 1 out of range for sequence of length 1`},
+		{"scalar", ed.Set("name.first", "x"), `can't edit key "name.first": "name" isn't a mapping or sequence`},
+	}
+	for _, tt := range tests {
+		if assert.Error(t, tt.err, tt.desc) {
+			assert.Equal(t, tt.want, tt.err.Error(), tt.desc)
+		}
+	}
+	assert.Equal(t, src, string(ed.Bytes()), "failed edits shouldn't change the file")
+
+	require.NoError(t, ed.Set("service.a", 2), "expected merged keys to be overridable")
+	// gopkg.in/yaml.v2 doesn't allow overriding merged keys in strict mode.
+	p, err := NewYAML(ed.Source(), Permissive())
+	require.NoError(t, err, "couldn't construct provider")
+	assert.Equal(t, 2, p.Get("service.a").Value(), "unexpected override")
+	assert.Equal(t, 1, p.Get("base.a").Value(), "expected anchor to be unchanged")
+
+	_, err = LoadEditor(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err, "expected an error loading a missing file")
+}
+
+func TestEditorSave(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "base.yaml")
+	require.NoError(t, ioutil.WriteFile(base, []byte("service: {replicas: 1, name: api}\n"), 0600), "couldn't write base")
+	ed := newEditor(t, _editorSource)
+	require.NoError(t, ed.Set("service.replicas", 5), "set failed")
+
+	before, err := NewYAML(File(base), File(ed.path))
+	require.NoError(t, err, "couldn't construct provider")
+	after, err := NewYAML(File(base), ed.Source())
+	require.NoError(t, err, "couldn't construct preview")
+	assert.Equal(t, []Change{{
+		Path: []string{"service", "replicas"}, Kind: Modified, Old: 3, New: 5,
+	}}, Diff(before, after, Root), "unexpected preview")
+
+	require.NoError(t, ed.Save(), "save failed")
+	saved, err := ioutil.ReadFile(ed.path)
+	require.NoError(t, err, "couldn't read saved file")
+	assert.Equal(t, ed.Bytes(), saved, "unexpected saved contents")
+	info, err := os.Stat(ed.path)
+	require.NoError(t, err, "couldn't stat saved file")
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "expected permissions to be kept")
+	entries, err := ioutil.ReadDir(filepath.Dir(ed.path))
+	require.NoError(t, err, "couldn't list directory")
+	assert.Len(t, entries, 1, "expected no temporary files to be left behind")
+}
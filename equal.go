@@ -28,8 +28,9 @@ import (
 )
 
 // areSameYAML checks whether two values represent the same YAML data. It's
-// only called from NewValue, where we must validate that the user-supplied
-// value matches the contents of the user-supplied provider.
+// used by NewValue, where we must validate that the user-supplied value
+// matches the contents of the user-supplied provider, and by Reloadable to
+// detect changes in subscribed keys.
 func areSameYAML(fromProvider, fromUser interface{}) (bool, error) {
 	p, err := yaml.Marshal(fromProvider)
 	if err != nil {
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetAs populates a value of type T from the configuration at the given key,
+// using the same rules (including strict mode, defaults, and decode hooks) as
+// Value.Populate. Unlike Populate, it returns an error if there's no
+// configuration at the key.
+//
+//	port, err := config.GetAs[int](provider, "server.port")
+func GetAs[T any](p Provider, key string) (T, error) {
+	var t T
+	v := p.Get(key)
+	if !v.HasValue() {
+		return t, fmt.Errorf("no configuration at key %q", key)
+	}
+	if err := v.Populate(&t); err != nil {
+		return t, getError[T](key, err)
+	}
+	return t, nil
+}
+
+// MustGetAs is like GetAs, but it panics on errors. It's intended for use
+// during process startup, when configuration errors are fatal.
+func MustGetAs[T any](p Provider, key string) T {
+	t, err := GetAs[T](p, key)
+	if err != nil {
+		panic(err.Error())
+	}
+	return t
+}
+
+// GetOr is like GetAs, but it returns the supplied default if there's no
+// configuration at the key. Configuration that's present but can't be
+// decoded into T is still an error, and an explicit null overrides the
+// default with T's zero value.
+func GetOr[T any](p Provider, key string, def T) (T, error) {
+	v := p.Get(key)
+	if !v.HasValue() {
+		return def, nil
+	}
+	var t T
+	if err := v.Populate(&t); err != nil {
+		return def, getError[T](key, err)
+	}
+	return t, nil
+}
+
+func getError[T any](key string, err error) error {
+	return fmt.Errorf("couldn't get key %q as %v: %v", key, reflect.TypeOf((*T)(nil)).Elem(), err)
+}
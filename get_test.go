@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAs(t *testing.T) {
+	p := mustYAML(t, `
+server:
+  port: 8080
+  timeout: 3s
+  tags: [a, b]
+  max_body: 1MiB
+  extra: {unknown: true}
+`)
+
+	port, err := GetAs[int](p, "server.port")
+	require.NoError(t, err, "couldn't get int")
+	assert.Equal(t, 8080, port, "unexpected int")
+
+	timeout, err := GetAs[time.Duration](p, "server.timeout")
+	require.NoError(t, err, "couldn't get duration")
+	assert.Equal(t, 3*time.Second, timeout, "unexpected duration")
+
+	tags, err := GetAs[[]string](p, "server.tags")
+	require.NoError(t, err, "couldn't get slice")
+	assert.Equal(t, []string{"a", "b"}, tags, "unexpected slice")
+
+	size, err := GetAs[ByteSize](p, "server.max_body")
+	require.NoError(t, err, "couldn't get byte size")
+	assert.Equal(t, Mebibyte, size, "unexpected byte size")
+
+	_, err = GetAs[int](p, "server.missing")
+	assert.EqualError(t, err, `no configuration at key "server.missing"`, "unexpected error for missing key")
+
+	_, err = GetAs[int](p, "server.tags")
+	require.Error(t, err, "expected type mismatch")
+	assert.Contains(t, err.Error(), `couldn't get key "server.tags" as int`, "unexpected error for type mismatch")
+
+	// Strict mode applies, just like Populate.
+	_, err = GetAs[struct{ Known bool }](p, "server.extra")
+	require.Error(t, err, "expected unknown field error")
+	assert.Contains(t, err.Error(), "field unknown not found", "unexpected error for unknown field")
+}
+
+func TestMustGetAs(t *testing.T) {
+	p := mustYAML(t, "port: 8080")
+	assert.Equal(t, 8080, MustGetAs[int](p, "port"), "unexpected value")
+	assert.PanicsWithValue(t, `no configuration at key "missing"`, func() {
+		MustGetAs[int](p, "missing")
+	}, "expected panic for missing key")
+}
+
+func TestGetOr(t *testing.T) {
+	p := mustYAML(t, "port: 8080\nname: ~\nbad: [1]")
+
+	port, err := GetOr(p, "port", 80)
+	require.NoError(t, err, "couldn't get present key")
+	assert.Equal(t, 8080, port, "expected configured value")
+
+	port, err = GetOr(p, "missing", 80)
+	require.NoError(t, err, "couldn't get missing key")
+	assert.Equal(t, 80, port, "expected default")
+
+	name, err := GetOr(p, "name", "svc")
+	require.NoError(t, err, "couldn't get null key")
+	assert.Equal(t, "", name, "explicit null should override the default")
+
+	_, err = GetOr(p, "bad", 80)
+	require.Error(t, err, "expected type mismatch")
+	assert.Contains(t, err.Error(), `couldn't get key "bad" as int`, "unexpected error")
+}
@@ -0,0 +1,593 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	_textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	_urlType             = reflect.TypeOf(url.URL{})
+	_regexpType          = reflect.TypeOf(regexp.Regexp{})
+	_locationType        = reflect.TypeOf(time.Location{})
+	_bigIntType          = reflect.TypeOf(big.Int{})
+
+	// Hooks applied after any registered with DecodeHook.
+	_builtinHooks = []decodeHook{
+		decodeDuration,
+		decodeURL,
+		decodeRegexp,
+		decodeLocation,
+		decodeBigInt,
+		decodeText,
+	}
+)
+
+type decodeHook func(from interface{}, to reflect.Type) (interface{}, error)
+
+// DecodeHook registers a function that converts configuration values as
+// Value.Populate decodes them into Go values. Populate calls the hook with
+// the unmarshalled configuration (or the previous hook's result) and the type
+// it's decoding into; hooks that don't apply should return the value
+// unchanged. If a hook returns a value of exactly the target type, Populate
+// uses it as-is. Otherwise, the result replaces the configuration value and
+// decoding continues as usual.
+//
+// Hooks run in the order they're registered, followed by built-in hooks for
+// time.Duration, url.URL, *regexp.Regexp, *time.Location, *big.Int, and any
+// type whose pointer implements encoding.TextUnmarshaler (including net.IP
+// and ByteSize). Since regexp.Regexp, time.Location, and big.Int values
+// mustn't be copied, decoding into them rather than pointers to them is an
+// error. For pointer types, hooks see both the pointer and the type it
+// points to. They're never called for null values, interface types, or types
+// that implement yaml.Unmarshaler.
+func DecodeHook(hook func(from interface{}, to reflect.Type) (interface{}, error)) YAMLOption {
+	return optionFunc(func(c *config) {
+		c.hooks = append(c.hooks, hook)
+	})
+}
+
+// A hookDecoder runs decode hooks over unmarshalled configuration before
+// it's decoded by gopkg.in/yaml.v2. Values converted by a hook are replaced
+// with nulls and set directly once yaml.v2 is done.
+//
+// gopkg.in/yaml.v3 drops nulls from sequences of types that can't be nil,
+// so if compact is set, sequences with converted elements are re-expanded
+// before the converted values are set.
+type hookDecoder struct {
+	hooks   []decodeHook
+	compact bool
+	assigns []hookAssignment
+	expands []hookExpansion
+}
+
+type hookAssignment struct {
+	steps []decodeStep
+	value reflect.Value
+}
+
+type hookExpansion struct {
+	steps   []decodeStep
+	present []bool // whether each element of the sequence wasn't null
+}
+
+// A decodeStep locates a Go value relative to its parent: it's a fieldStep,
+// indexStep, keyStep, or elemStep.
+type decodeStep interface{}
+
+type (
+	fieldStep int                       // struct field index
+	indexStep int                       // slice or array index
+	keyStep   struct{ key interface{} } // unmarshalled map key
+	elemStep  struct{}                  // pointer dereference
+)
+
+func newHookDecoder(hooks []decodeHook, compact bool) *hookDecoder {
+	all := make([]decodeHook, 0, len(hooks)+len(_builtinHooks))
+	all = append(all, hooks...)
+	return &hookDecoder{hooks: append(all, _builtinHooks...), compact: compact}
+}
+
+// decode runs hooks over a value that will be decoded into type t. It
+// returns the value to hand to yaml.v2 and whether it differs from the input.
+// Like applyDefaults, it copies mappings and sequences before changing them.
+func (d *hookDecoder) decode(t reflect.Type, val interface{}, path []string, steps []decodeStep) (interface{}, bool, error) {
+	if val == nil || t.Kind() == reflect.Interface || reflect.PtrTo(t).Implements(_unmarshalerType) {
+		return val, false, nil
+	}
+
+	val, done, changed, err := d.convert(t, val, path)
+	if err != nil {
+		return nil, false, err
+	}
+	if done {
+		d.assigns = append(d.assigns, hookAssignment{
+			steps: steps,
+			value: reflect.ValueOf(val),
+		})
+		return nil, true, nil
+	}
+	if val == nil {
+		return nil, changed, nil
+	}
+
+	var (
+		out        interface{}
+		outChanged bool
+	)
+	switch t.Kind() {
+	case reflect.Ptr:
+		out, outChanged, err = d.decode(t.Elem(), val, path, appendStep(steps, elemStep{}))
+	case reflect.Struct:
+		if m, ok := val.(map[interface{}]interface{}); ok {
+			out, outChanged, err = d.decodeStruct(t, m, path, steps)
+		}
+	case reflect.Slice, reflect.Array:
+		if s, ok := val.([]interface{}); ok {
+			out, outChanged, err = d.decodeSequence(t.Elem(), s, path, steps)
+		}
+	case reflect.Map:
+		if m, ok := val.(map[interface{}]interface{}); ok {
+			out, outChanged, err = d.decodeMap(t.Elem(), m, path, steps, nil)
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if outChanged {
+		return out, true, nil
+	}
+	return val, changed, nil
+}
+
+// convert runs the hooks for a single value, without descending into it. If
+// a hook converted the value to type t, it returns the result and done.
+func (d *hookDecoder) convert(t reflect.Type, val interface{}, path []string) (out interface{}, done, changed bool, err error) {
+	for _, hook := range d.hooks {
+		out, err := hook(val, t)
+		if err != nil {
+			return nil, false, false, decodeError(path, err)
+		}
+		if out != nil && reflect.TypeOf(out) == t && !isPlainYAML(out) {
+			return out, true, true, nil
+		}
+		if !sameValue(out, val) {
+			val, changed = out, true
+		}
+		if val == nil {
+			break
+		}
+	}
+	return val, false, changed, nil
+}
+
+func (d *hookDecoder) decodeStruct(t reflect.Type, m map[interface{}]interface{}, path []string, steps []decodeStep) (interface{}, bool, error) {
+	var copied map[interface{}]interface{}
+	set := func(k, v interface{}) {
+		if copied == nil {
+			copied = copyMapping(m)
+		}
+		copied[k] = v
+	}
+	if err := d.decodeFields(t, m, path, steps, set); err != nil {
+		return nil, false, err
+	}
+	if copied == nil {
+		return m, false, nil
+	}
+	return copied, true, nil
+}
+
+// decodeFields decodes a struct's fields from a mapping. Inlined structs and
+// maps share their parent's mapping.
+func (d *hookDecoder) decodeFields(t reflect.Type, m map[interface{}]interface{}, path []string, steps []decodeStep, set func(k, v interface{})) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		fieldSteps := appendStep(steps, fieldStep(i))
+		if inline {
+			var err error
+			switch f.Type.Kind() {
+			case reflect.Struct:
+				err = d.decodeFields(f.Type, m, path, fieldSteps, set)
+			case reflect.Map:
+				known := make(map[interface{}]bool)
+				collectFieldNames(t, known)
+				_, _, err = d.decodeMap(f.Type.Elem(), m, path, fieldSteps, func(k, v interface{}) {
+					if !known[k] {
+						set(k, v)
+					}
+				})
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		v, found := m[name]
+		if !found {
+			continue
+		}
+		v, changed, err := d.decode(f.Type, v, extend(path, name), fieldSteps)
+		if err != nil {
+			return err
+		}
+		if changed {
+			set(name, v)
+		}
+	}
+	return nil
+}
+
+func (d *hookDecoder) decodeSequence(t reflect.Type, s []interface{}, path []string, steps []decodeStep) (interface{}, bool, error) {
+	var copied []interface{}
+	for i := range s {
+		v, changed, err := d.decode(t, s[i], extend(path, strconv.Itoa(i)), appendStep(steps, indexStep(i)))
+		if err != nil {
+			return nil, false, err
+		}
+		if !changed {
+			continue
+		}
+		if copied == nil {
+			copied = make([]interface{}, len(s))
+			copy(copied, s)
+		}
+		copied[i] = v
+	}
+	if copied == nil {
+		return s, false, nil
+	}
+	if d.compact && !isNullable(t) {
+		present := make([]bool, len(copied))
+		dropped := false
+		for i, v := range copied {
+			present[i] = v != nil
+			dropped = dropped || v == nil
+		}
+		if dropped {
+			d.expands = append(d.expands, hookExpansion{steps: steps, present: present})
+		}
+	}
+	return copied, true, nil
+}
+
+// decodeMap decodes the values of a mapping. If inline is non-nil, the
+// mapping belongs to a struct with an inlined map, so changes are passed to
+// inline rather than copied.
+func (d *hookDecoder) decodeMap(t reflect.Type, m map[interface{}]interface{}, path []string, steps []decodeStep, inline func(k, v interface{})) (interface{}, bool, error) {
+	var copied map[interface{}]interface{}
+	for _, k := range sortedKeys(m) {
+		v, changed, err := d.decode(t, m[k], extend(path, fmt.Sprint(k)), appendStep(steps, keyStep{k}))
+		if err != nil {
+			return nil, false, err
+		}
+		if !changed {
+			continue
+		}
+		if inline != nil {
+			inline(k, v)
+			continue
+		}
+		if copied == nil {
+			copied = copyMapping(m)
+		}
+		copied[k] = v
+	}
+	if copied == nil {
+		return m, false, nil
+	}
+	return copied, true, nil
+}
+
+// apply sets the values converted by hooks, once yaml.v2 has populated the
+// rest of the target.
+func (d *hookDecoder) apply(target reflect.Value) error {
+	// Sequences are recorded after the sequences nested within them, so
+	// expand in reverse to keep the steps to nested sequences valid.
+	for i := len(d.expands) - 1; i >= 0; i-- {
+		e := d.expands[i]
+		if err := updateAt(target, e.steps, func(v reflect.Value) {
+			expandSequence(v, e.present)
+		}); err != nil {
+			return err
+		}
+	}
+	for _, a := range d.assigns {
+		if err := updateAt(target, a.steps, func(v reflect.Value) {
+			v.Set(a.value)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandSequence moves the elements of a slice or array that was decoded
+// without its nulls back to their original indexes.
+func expandSequence(v reflect.Value, present []bool) {
+	var out reflect.Value
+	if v.Kind() == reflect.Slice {
+		out = reflect.MakeSlice(v.Type(), len(present), len(present))
+	} else {
+		out = reflect.New(v.Type()).Elem()
+	}
+	j := 0
+	for i, ok := range present {
+		if ok && j < v.Len() && i < out.Len() {
+			out.Index(i).Set(v.Index(j))
+			j++
+		}
+	}
+	v.Set(out)
+}
+
+func isNullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateAt calls update with the Go value located by steps.
+func updateAt(v reflect.Value, steps []decodeStep, update func(reflect.Value)) error {
+	if len(steps) == 0 {
+		update(v)
+		return nil
+	}
+	switch s := steps[0].(type) {
+	case fieldStep:
+		return updateAt(v.Field(int(s)), steps[1:], update)
+	case indexStep:
+		return updateAt(v.Index(int(s)), steps[1:], update)
+	case elemStep:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return updateAt(v.Elem(), steps[1:], update)
+	case keyStep:
+		// Map elements aren't addressable, so we decode the key the same way
+		// yaml.v2 did, then copy the element out and back.
+		key := reflect.New(v.Type().Key())
+		raw, err := yaml.Marshal(s.key)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(raw, key.Interface()); err != nil {
+			return err
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if cur := v.MapIndex(key.Elem()); cur.IsValid() {
+			elem.Set(cur)
+		}
+		if err := updateAt(elem, steps[1:], update); err != nil {
+			return err
+		}
+		v.SetMapIndex(key.Elem(), elem)
+		return nil
+	}
+	return fmt.Errorf("unknown decode step %T", steps[0])
+}
+
+// collectFieldNames records the keys claimed by a struct's fields, including
+// the fields of inlined structs.
+func collectFieldNames(t reflect.Type, names map[interface{}]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		if inline {
+			if f.Type.Kind() == reflect.Struct {
+				collectFieldNames(f.Type, names)
+			}
+			continue
+		}
+		names[name] = true
+	}
+}
+
+func sortedKeys(m map[interface{}]interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sortKeys(keys)
+	return keys
+}
+
+func appendStep(steps []decodeStep, s decodeStep) []decodeStep {
+	extended := make([]decodeStep, len(steps), len(steps)+1)
+	copy(extended, steps)
+	return append(extended, s)
+}
+
+func decodeError(path []string, err error) error {
+	return &hookError{path: path, err: err}
+}
+
+// A hookError is returned by a failed decode hook.
+type hookError struct {
+	path []string
+	err  error
+}
+
+func (e *hookError) Error() string {
+	if len(e.path) == 0 {
+		return fmt.Sprintf("couldn't decode configuration: %v", e.err)
+	}
+	return fmt.Sprintf("couldn't decode %s: %v", joinPath(e.path), e.err)
+}
+
+// isPlainYAML reports whether a value is one that gopkg.in/yaml.v2 produces
+// when unmarshalling into an interface{}.
+func isPlainYAML(v interface{}) bool {
+	switch v.(type) {
+	case bool, int, int64, uint64, float64, string, []interface{}, map[interface{}]interface{}:
+		return true
+	}
+	return false
+}
+
+// sameValue reports whether a hook returned its input, without comparing
+// mappings or sequences element by element.
+func sameValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb {
+		return false
+	}
+	if ta.Comparable() {
+		return a == b
+	}
+	switch ta.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+		return va.Pointer() == vb.Pointer() && (ta.Kind() != reflect.Slice || va.Len() == vb.Len())
+	}
+	return false
+}
+
+func decodeDuration(from interface{}, to reflect.Type) (interface{}, error) {
+	s, ok := from.(string)
+	if !ok || to != _durationType {
+		return from, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func decodeURL(from interface{}, to reflect.Type) (interface{}, error) {
+	s, ok := from.(string)
+	if !ok || (to != _urlType && to != reflect.PtrTo(_urlType)) {
+		return from, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if to == _urlType {
+		return *u, nil
+	}
+	return u, nil
+}
+
+func decodeRegexp(from interface{}, to reflect.Type) (interface{}, error) {
+	s, ok := from.(string)
+	if !ok || (to != _regexpType && to != reflect.PtrTo(_regexpType)) {
+		return from, nil
+	}
+	if to == _regexpType {
+		return nil, errCopy(to)
+	}
+	return regexp.Compile(s)
+}
+
+func decodeLocation(from interface{}, to reflect.Type) (interface{}, error) {
+	s, ok := from.(string)
+	if !ok || (to != _locationType && to != reflect.PtrTo(_locationType)) {
+		return from, nil
+	}
+	if to == _locationType {
+		return nil, errCopy(to)
+	}
+	return time.LoadLocation(s)
+}
+
+func decodeBigInt(from interface{}, to reflect.Type) (interface{}, error) {
+	if to != _bigIntType && to != reflect.PtrTo(_bigIntType) {
+		return from, nil
+	}
+	if to == _bigIntType {
+		return nil, errCopy(to)
+	}
+	n := new(big.Int)
+	switch v := from.(type) {
+	case int:
+		n.SetInt64(int64(v))
+	case int64:
+		n.SetInt64(v)
+	case uint64:
+		n.SetUint64(v)
+	case float64:
+		// YAML integers too large for uint64 are parsed as floats, which
+		// can't hold them exactly.
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("%v isn't an exact integer; quote large integers", v)
+		}
+		new(big.Float).SetFloat64(v).Int(n)
+	case string:
+		if _, ok := n.SetString(v, 0); !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+	default:
+		return from, nil
+	}
+	return n, nil
+}
+
+// errCopy rejects decoding into a type whose values mustn't be copied, like
+// big.Int, since Populate would have to copy the decoded value into place.
+func errCopy(t reflect.Type) error {
+	return fmt.Errorf("can't decode into a %v, which mustn't be copied; use *%v instead", t, t)
+}
+
+// decodeText honors encoding.TextUnmarshaler for scalars.
+func decodeText(from interface{}, to reflect.Type) (interface{}, error) {
+	if !reflect.PtrTo(to).Implements(_textUnmarshalerType) {
+		return from, nil
+	}
+	var text string
+	switch v := from.(type) {
+	case string:
+		text = v
+	case bool, int, int64, uint64:
+		text = fmt.Sprint(v)
+	case float64:
+		text = strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return from, nil
+	}
+	ptr := reflect.New(to)
+	if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface(), nil
+}
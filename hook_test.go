@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hooksEndpoint struct {
+	URL     *url.URL
+	Allowed []net.IP
+}
+
+type hooksExtra struct {
+	Zone *time.Location
+}
+
+type hooksConfig struct {
+	hooksExtra `yaml:",inline"`
+
+	Timeout   time.Duration
+	Base      url.URL
+	Pattern   *regexp.Regexp
+	Loc       *time.Location
+	Big       *big.Int
+	Huge      *big.Int
+	IP        net.IP
+	MaxBody   ByteSize `yaml:"max_body" default:"1KiB"`
+	Endpoints map[string]hooksEndpoint
+	Untouched string
+}
+
+func TestPopulateBuiltinHooks(t *testing.T) {
+	p := mustYAML(t, `
+svc:
+  zone: UTC
+  timeout: 2s
+  base: https://example.com/api
+  pattern: ^a+$
+  loc: UTC
+  big: 12345678901234567890
+  huge: "0x10000000000000000"
+  ip: 10.0.0.1
+  endpoints:
+    primary:
+      url: http://localhost:8080
+      allowed: [127.0.0.1, '::1']
+  untouched: hello
+`)
+
+	var cfg hooksConfig
+	require.NoError(t, p.Get("svc").Populate(&cfg), "populate failed")
+
+	assert.Equal(t, "UTC", cfg.Zone.String(), "unexpected inlined location")
+	assert.Equal(t, 2*time.Second, cfg.Timeout, "unexpected duration")
+	assert.Equal(t, "https://example.com/api", cfg.Base.String(), "unexpected URL")
+	require.NotNil(t, cfg.Pattern, "expected a regexp")
+	assert.True(t, cfg.Pattern.MatchString("aaa"), "unexpected regexp")
+	assert.Equal(t, "UTC", cfg.Loc.String(), "unexpected location")
+	assert.Equal(t, "12345678901234567890", cfg.Big.String(), "unexpected big.Int")
+	assert.Equal(t, "18446744073709551616", cfg.Huge.String(), "unexpected big.Int from hex")
+	assert.Equal(t, net.ParseIP("10.0.0.1"), cfg.IP, "unexpected IP")
+	assert.Equal(t, Kibibyte, cfg.MaxBody, "expected default byte size")
+	require.Contains(t, cfg.Endpoints, "primary", "missing map entry")
+	assert.Equal(t, "localhost:8080", cfg.Endpoints["primary"].URL.Host, "unexpected URL in map")
+	assert.Equal(t, []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")}, cfg.Endpoints["primary"].Allowed, "unexpected IPs in slice")
+	assert.Equal(t, "hello", cfg.Untouched, "unexpected string")
+
+	// Hooks mustn't modify the provider's contents.
+	assert.Equal(t, "2s", p.Get("svc.timeout").Value(), "provider contents changed")
+	assert.Equal(t, "https://example.com/api", p.Get("svc.base").Value(), "provider contents changed")
+}
+
+func TestPopulateHookErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		yaml   string
+		target interface{}
+		err    string
+	}{
+		{
+			desc:   "duration",
+			yaml:   "svc: {timeout: soon}",
+			target: &hooksConfig{},
+			err:    "couldn't decode svc.timeout",
+		},
+		{
+			desc:   "regexp",
+			yaml:   "svc: {pattern: '('}",
+			target: &hooksConfig{},
+			err:    "couldn't decode svc.pattern",
+		},
+		{
+			desc:   "location",
+			yaml:   "svc: {loc: Nowhere/Special}",
+			target: &hooksConfig{},
+			err:    "couldn't decode svc.loc",
+		},
+		{
+			desc:   "inexact big.Int",
+			yaml:   "svc: {big: 123456789012345678901234567890}",
+			target: &hooksConfig{},
+			err:    "quote large integers",
+		},
+		{
+			desc:   "big.Int value",
+			yaml:   "svc: {count: 1}",
+			target: &struct{ Count big.Int }{},
+			err:    "couldn't decode svc.count: can't decode into a big.Int, which mustn't be copied; use *big.Int instead",
+		},
+		{
+			desc:   "regexp.Regexp value",
+			yaml:   "svc: {re: a}",
+			target: &struct{ RE regexp.Regexp }{},
+			err:    "use *regexp.Regexp instead",
+		},
+		{
+			desc:   "time.Location value",
+			yaml:   "svc: {loc: UTC}",
+			target: &struct{ Loc time.Location }{},
+			err:    "use *time.Location instead",
+		},
+		{
+			desc:   "text unmarshaler in map",
+			yaml:   "svc: {endpoints: {primary: {allowed: [not-an-ip]}}}",
+			target: &hooksConfig{},
+			err:    "couldn't decode svc.endpoints.primary.allowed.0",
+		},
+		{
+			desc:   "byte size",
+			yaml:   "svc: {max_body: 10 parsecs}",
+			target: &hooksConfig{},
+			err:    `couldn't decode svc.max_body: invalid byte size "10 parsecs"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := mustYAML(t, tt.yaml).Get("svc").Populate(tt.target)
+			require.Error(t, err, "expected populate to fail")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error message")
+		})
+	}
+}
+
+type upper string
+
+func TestDecodeHook(t *testing.T) {
+	var calls []reflect.Type
+	hook := DecodeHook(func(from interface{}, to reflect.Type) (interface{}, error) {
+		calls = append(calls, to)
+		s, ok := from.(string)
+		switch {
+		case !ok:
+			return from, nil
+		case to == reflect.TypeOf(upper("")):
+			return upper(strings.ToUpper(s)), nil
+		case to == _durationType && s == "forever":
+			return "8760h", nil // handed on to the built-in hook
+		case s == "fail":
+			return nil, errors.New("hook failed")
+		}
+		return from, nil
+	})
+
+	p, err := NewYAML(hook, Source(strings.NewReader(`
+name: svc
+timeout: forever
+tags: [a, b]
+`)))
+	require.NoError(t, err, "couldn't create provider")
+
+	var cfg struct {
+		Name    upper
+		Timeout time.Duration
+		Tags    []upper
+		Other   interface{}
+	}
+	require.NoError(t, p.Get(Root).Populate(&cfg), "populate failed")
+	assert.Equal(t, upper("SVC"), cfg.Name, "unexpected hooked string")
+	assert.Equal(t, 8760*time.Hour, cfg.Timeout, "unexpected chained duration")
+	assert.Equal(t, []upper{"A", "B"}, cfg.Tags, "unexpected hooked slice")
+	assert.NotContains(t, calls, reflect.TypeOf((*interface{})(nil)).Elem(), "hooks shouldn't see interface types")
+
+	t.Run("survives WithDefault", func(t *testing.T) {
+		v, err := p.Get("name").WithDefault("default")
+		require.NoError(t, err, "WithDefault failed")
+		var name upper
+		require.NoError(t, v.Populate(&name), "populate failed")
+		assert.Equal(t, upper("SVC"), name, "hook lost by WithDefault")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		p, err := NewYAML(hook, Source(strings.NewReader("name: fail")))
+		require.NoError(t, err, "couldn't create provider")
+		var s string
+		err = p.Get("name").Populate(&s)
+		require.Error(t, err, "expected hook error")
+		assert.Equal(t, "couldn't decode name: hook failed", err.Error(), "unexpected error")
+	})
+}
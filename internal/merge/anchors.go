@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package merge
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	yaml "gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var (
+	_unknownAnchor = regexp.MustCompile(`unknown anchor '([^']*)' referenced`)
+	_lineNumber    = regexp.MustCompile(`\bline (\d+)`)
+)
+
+// A Document is a single YAML document from one of several sources, prepared
+// for decoding by Prepare.
+type Document struct {
+	// Source is the index of the source that contains the document.
+	Source int
+	// Bytes holds the YAML to decode. Use Unwrap or UnwrapNode to extract
+	// the document from the decoded value.
+	Bytes []byte
+
+	wrapped bool
+	offset  int // lines added before the document by wrapping
+}
+
+// Prepare splits sources into documents (see Documents) and lets each
+// document use the anchors defined by earlier documents, including those in
+// lower-priority sources. Normally, YAML anchors are local to a document.
+//
+// Aliases refer to the most recent definition of an anchor. They copy the
+// anchored value as it's written in the defining document, before any
+// merging, so overriding part of that value in a later source doesn't change
+// the alias. An alias that doesn't refer to any earlier anchor is an error.
+//
+// Documents are checked with the parser that will decode them:
+// gopkg.in/yaml.v3 if v3 is set, and gopkg.in/yaml.v2 otherwise. Documents
+// that the parser accepts on their own are returned as-is. Others, which
+// refer to anchors from elsewhere, are wrapped in a sequence, preceded by
+// the earlier documents that define anchors. Wrapping may shift line
+// numbers, which Err and UnwrapNode correct.
+func Prepare(sources [][]byte, v3 bool) ([]Document, error) {
+	var (
+		docs     []Document
+		preamble bytes.Buffer // earlier documents defining anchors
+	)
+	for i, src := range sources {
+		for _, raw := range Documents(src) {
+			doc := Document{Source: i, Bytes: raw}
+			anchors, err := parse(doc, raw, v3)
+			if _, ok := unknownAnchor(err); ok {
+				doc = wrap(i, raw, preamble.Bytes())
+				anchors, err = parse(doc, raw, v3)
+				// The preamble has every earlier anchor, so any anchor that's
+				// still unknown is undefined.
+				if name, ok := unknownAnchor(err); ok {
+					return nil, fmt.Errorf("alias *%s doesn't refer to an anchor in this or an earlier source", name)
+				}
+			}
+			if err == nil && anchors && embeds(i, raw, preamble.Bytes(), v3) {
+				preamble.WriteString("-\n")
+				writeIndented(&preamble, trimBlankLines(embeddable(raw)))
+			}
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// Unwrap extracts the document from a value decoded from Bytes.
+func (d Document) Unwrap(v interface{}) interface{} {
+	if !d.wrapped {
+		return v
+	}
+	if s, ok := v.([]interface{}); ok && len(s) > 0 {
+		return s[len(s)-1]
+	}
+	return nil
+}
+
+// UnwrapNode extracts the root node of the document from a node decoded from
+// Bytes, correcting the positions of the document's nodes. Nodes from other
+// documents, which are only reachable through aliases, have no position. It
+// returns nil if the document is empty.
+func (d Document) UnwrapNode(n *yamlv3.Node) *yamlv3.Node {
+	if n == nil || n.Kind != yamlv3.DocumentNode || len(n.Content) == 0 {
+		return nil
+	}
+	root := n.Content[0]
+	if !d.wrapped {
+		return root
+	}
+	if root.Kind != yamlv3.SequenceNode || len(root.Content) == 0 {
+		return nil
+	}
+	for _, other := range root.Content[:len(root.Content)-1] {
+		walkNodes(other, func(n *yamlv3.Node) {
+			n.Line, n.Column = 0, 0
+		})
+	}
+	doc := root.Content[len(root.Content)-1]
+	walkNodes(doc, func(n *yamlv3.Node) {
+		n.Line -= d.offset
+		n.Column -= 2
+	})
+	return doc
+}
+
+// Err corrects the line numbers in an error from decoding Bytes.
+func (d Document) Err(err error) error {
+	if err == nil || d.offset == 0 {
+		return err
+	}
+	msg := _lineNumber.ReplaceAllStringFunc(err.Error(), func(s string) string {
+		n, _ := strconv.Atoi(s[len("line "):])
+		return "line " + strconv.Itoa(n-d.offset)
+	})
+	return fmt.Errorf("%s", msg)
+}
+
+// wrap makes a document the last element of a sequence that begins with the
+// preamble. If the document starts with enough blank or comment-only lines,
+// the preamble replaces them, keeping the document's lines in place.
+func wrap(source int, raw, preamble []byte) Document {
+	lines := bytes.SplitAfter(embeddable(raw), []byte("\n"))
+	lead := 0 // leading blank and comment lines
+	for lead < len(lines)-1 {
+		trimmed := bytes.TrimSpace(lines[lead])
+		if len(trimmed) > 0 && trimmed[0] != '#' {
+			break
+		}
+		lead++
+	}
+	offset := bytes.Count(preamble, []byte("\n")) + 1 - lead
+
+	var buf bytes.Buffer
+	for ; offset < 0; offset++ {
+		buf.WriteByte('\n')
+	}
+	buf.Write(preamble)
+	buf.WriteString("-\n")
+	writeIndented(&buf, bytes.Join(lines[lead:], nil))
+	return Document{Source: source, Bytes: buf.Bytes(), wrapped: true, offset: offset}
+}
+
+// embeddable removes document markers and directives from a document, so
+// that it can be nested in another.
+func embeddable(doc []byte) []byte {
+	var buf bytes.Buffer
+	marked := false
+	for _, line := range bytes.SplitAfter(doc, []byte("\n")) {
+		switch {
+		case isMarker(line, "---"):
+			marked = true
+			buf.WriteString("   ")
+			buf.Write(line[3:])
+		case isMarker(line, "..."), !marked && bytes.HasPrefix(line, []byte("%")):
+			if bytes.HasSuffix(line, []byte("\n")) {
+				buf.WriteByte('\n')
+			}
+		default:
+			buf.Write(line)
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeIndented writes YAML indented by two spaces. Blank lines are written
+// as-is.
+func writeIndented(buf *bytes.Buffer, yaml []byte) {
+	for _, line := range bytes.SplitAfter(yaml, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			buf.WriteString("  ")
+		}
+		buf.Write(line)
+	}
+	if len(yaml) > 0 && yaml[len(yaml)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+}
+
+func trimBlankLines(doc []byte) []byte {
+	lines := bytes.SplitAfter(doc, []byte("\n"))
+	start, end := 0, len(lines)
+	for start < end && len(bytes.TrimSpace(lines[start])) == 0 {
+		start++
+	}
+	for end > start && len(bytes.TrimSpace(lines[end-1])) == 0 {
+		end--
+	}
+	return bytes.Join(lines[start:end], nil)
+}
+
+// parse decodes a document, which was prepared from raw, with the selected
+// parser. It reports whether raw defines any anchors.
+func parse(doc Document, raw []byte, v3 bool) (bool, error) {
+	if v3 {
+		var n yamlv3.Node
+		if err := yamlv3.Unmarshal(doc.Bytes, &n); err != nil {
+			return false, err
+		}
+		return definesAnchor(doc.UnwrapNode(&n)), nil
+	}
+	var v interface{}
+	if err := yaml.Unmarshal(doc.Bytes, &v); err != nil {
+		return false, err
+	}
+	// yaml.v2 doesn't expose anchors, so look for their indicator instead. A
+	// false positive only adds an unused document to later preambles.
+	return bytes.Contains(raw, []byte("&")), nil
+}
+
+// embeds reports whether a document still parses when it's wrapped. yaml.v2
+// ignores some trailing garbage in top-level documents, for example, but not
+// in nested ones.
+func embeds(source int, raw, preamble []byte, v3 bool) bool {
+	_, err := parse(wrap(source, raw, preamble), raw, v3)
+	return err == nil
+}
+
+func unknownAnchor(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	m := _unknownAnchor.FindStringSubmatch(err.Error())
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+func definesAnchor(n *yamlv3.Node) bool {
+	found := false
+	walkNodes(n, func(n *yamlv3.Node) {
+		found = found || n.Anchor != ""
+	})
+	return found
+}
+
+// walkNodes calls f with each node in a tree, without following aliases.
+func walkNodes(n *yamlv3.Node, f func(*yamlv3.Node)) {
+	if n == nil {
+		return
+	}
+	f(n)
+	for _, c := range n.Content {
+		walkNodes(c, f)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func TestSharedAnchors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		sources []string
+		want    string
+	}{
+		{
+			desc: "alias and merge key",
+			sources: []string{
+				"defaults: &defaults\n  timeout: 1s\n  retries: 3\n",
+				"service:\n  <<: *defaults\n  name: svc\nother: *defaults\n",
+			},
+			want: "defaults:\n  retries: 3\n  timeout: 1s\n" +
+				"other:\n  retries: 3\n  timeout: 1s\n" +
+				"service:\n  name: svc\n  retries: 3\n  timeout: 1s\n",
+		},
+		{
+			desc: "alias copies unmerged value",
+			sources: []string{
+				"defaults: &defaults {timeout: 1s}\n",
+				"defaults: {timeout: 2s}\n",
+				"service: *defaults\n",
+			},
+			want: "defaults:\n  timeout: 2s\nservice:\n  timeout: 1s\n",
+		},
+		{
+			desc: "later definition shadows earlier",
+			sources: []string{
+				"a: &x 1\n",
+				"b: &x 2\n",
+				"c: *x\n",
+			},
+			want: "a: 1\nb: 2\nc: 2\n",
+		},
+		{
+			desc: "chained through aliasing source",
+			sources: []string{
+				"a: &x 1\n",
+				"b: &y [*x]\n",
+				"c: *y\n",
+			},
+			want: "a: 1\nb:\n- 1\nc:\n- 1\n",
+		},
+		{
+			desc: "documents in one source",
+			sources: []string{
+				"%YAML 1.1\n--- &x\na: 1\n...\n---\nb: *x\n",
+			},
+			want: "a: 1\nb:\n  a: 1\n",
+		},
+		{
+			desc: "block scalars",
+			sources: []string{
+				"text: &text |\n  one\n\n  two\n",
+				"copy: *text\n",
+			},
+			want: "copy: |\n  one\n\n  two\ntext: |\n  one\n\n  two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sources := make([][]byte, len(tt.sources))
+			for i, s := range tt.sources {
+				sources[i] = []byte(s)
+			}
+			merged, err := YAML(sources, true /* strict */)
+			require.NoError(t, err, "merge failed")
+			assert.Equal(t, tt.want, merged.String(), "unexpected merge")
+		})
+	}
+}
+
+func TestUndefinedAnchors(t *testing.T) {
+	sources := [][]byte{
+		[]byte("a: &x 1\n"),
+		[]byte("b: *x\nc: *missing\n"),
+	}
+
+	for _, strict := range []bool{true, false} {
+		_, err := YAML(sources, strict)
+		require.Error(t, err, "expected an error (strict: %v)", strict)
+		assert.Contains(t, err.Error(), "alias *missing doesn't refer to an anchor", "unexpected error")
+	}
+}
+
+func TestPrepareWithYAMLv2(t *testing.T) {
+	// yaml.v2 ignores the trailing bracket in the second source, but yaml.v3
+	// doesn't. Documents are only checked with the parser that decodes them.
+	sources := [][]byte{
+		[]byte("a: &x 1\n"),
+		[]byte("{c: &y 1} ]\n"),
+		[]byte("b: *x\n"),
+	}
+	docs, err := Prepare(sources, false /* v3 */)
+	require.NoError(t, err, "prepare failed")
+	require.Len(t, docs, 3, "unexpected number of documents")
+	assert.Equal(t, sources[1], docs[1].Bytes, "expected document without aliases to be unchanged")
+
+	merged, err := YAML(sources, false /* strict */)
+	require.NoError(t, err, "merge failed")
+	assert.Equal(t, "a: 1\nb: 1\nc: 1\n", merged.String(), "unexpected merge")
+}
+
+func TestPreparePositions(t *testing.T) {
+	sources := [][]byte{
+		[]byte("base: &base\n  a: 1\n"),
+		[]byte("x: *base\ny:\n  <<: *base\n  b: 2\n"),
+		[]byte("# padding\n\n\n\n\n\nz: *base\n"),
+	}
+	docs, err := Prepare(sources, true /* v3 */)
+	require.NoError(t, err, "prepare failed")
+	require.Len(t, docs, 3, "unexpected number of documents")
+	assert.Equal(t, sources[0], docs[0].Bytes, "expected document without aliases to be unchanged")
+
+	t.Run("nodes", func(t *testing.T) {
+		var n yamlv3.Node
+		require.NoError(t, yamlv3.Unmarshal(docs[1].Bytes, &n), "couldn't parse wrapped document")
+		root := docs[1].UnwrapNode(&n)
+		require.NotNil(t, root, "expected a root node")
+		require.Equal(t, yamlv3.MappingNode, root.Kind, "unexpected root")
+		y := root.Content[2]
+		assert.Equal(t, "y", y.Value, "unexpected key")
+		assert.Equal(t, []int{2, 1}, []int{y.Line, y.Column}, "unexpected key position")
+		b := root.Content[3].Content[2]
+		assert.Equal(t, []int{4, 3}, []int{b.Line, b.Column}, "unexpected nested position")
+		alias := root.Content[1].Alias
+		assert.Equal(t, []int{0, 0}, []int{alias.Line, alias.Column}, "expected no position for aliased node")
+	})
+
+	t.Run("blank lines", func(t *testing.T) {
+		assert.Equal(t, 0, docs[2].offset, "expected leading blank lines to hold the preamble")
+		var n yamlv3.Node
+		require.NoError(t, yamlv3.Unmarshal(docs[2].Bytes, &n), "couldn't parse wrapped document")
+		z := docs[2].UnwrapNode(&n).Content[0]
+		assert.Equal(t, []int{7, 1}, []int{z.Line, z.Column}, "unexpected position")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := YAML([][]byte{
+			[]byte("base: &base {a: 1}\n"),
+			[]byte("x: *base\ny: 1\ny: 2\n"),
+		}, true /* strict */)
+		require.Error(t, err, "expected duplicate key error")
+		assert.True(t, strings.Contains(err.Error(), "line 3:"), "expected corrected line number in %q", err.Error())
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package merge
+
+import "bytes"
+
+// Documents splits a YAML source into its documents, in order. To keep line
+// numbers meaningful, each document is returned as a copy of the whole source
+// with every line outside the document blanked.
+//
+// Documents that contain only comments are dropped, so that separators at
+// the start or end of a source don't introduce empty documents, which would
+// otherwise decode as explicit nulls. If there are no other documents, the
+// source is returned unchanged.
+//
+// Rather than parsing the source, Documents looks for lines that start with a
+// document marker ("---" or "..."). YAML doesn't allow those lines within
+// documents, even within multi-line strings.
+func Documents(src []byte) [][]byte {
+	lines := bytes.SplitAfter(src, []byte("\n"))
+
+	type document struct {
+		start, end int // range of lines
+		content    bool
+	}
+	var docs []document
+	cur := document{}
+	marked := false // whether cur has a "---" marker
+	finish := func(end int) {
+		cur.end = end
+		if cur.content {
+			docs = append(docs, cur)
+		}
+		cur = document{start: end}
+		marked = false
+	}
+
+	for i, line := range lines {
+		switch {
+		case isMarker(line, "---"):
+			// Comments and directives before the first marker belong to the
+			// document that the marker starts.
+			if cur.content || marked {
+				finish(i)
+			}
+			marked = true
+			if rest := bytes.TrimSpace(line[3:]); len(rest) > 0 && rest[0] != '#' {
+				cur.content = true
+			}
+		case isMarker(line, "..."):
+			finish(i + 1)
+		default:
+			trimmed := bytes.TrimSpace(line)
+			if len(trimmed) == 0 || trimmed[0] == '#' || !marked && trimmed[0] == '%' {
+				continue
+			}
+			cur.content = true
+		}
+	}
+	finish(len(lines))
+
+	if len(docs) == 0 || len(docs) == 1 && docs[0].start == 0 && docs[0].end == len(lines) {
+		return [][]byte{src}
+	}
+
+	split := make([][]byte, len(docs))
+	for i, doc := range docs {
+		var buf bytes.Buffer
+		buf.Grow(len(src))
+		for j, line := range lines {
+			if j >= doc.start && j < doc.end {
+				buf.Write(line)
+			} else if bytes.HasSuffix(line, []byte("\n")) {
+				buf.WriteByte('\n')
+			}
+		}
+		split[i] = buf.Bytes()
+	}
+	return split
+}
+
+// isMarker reports whether a line starts with a document marker.
+func isMarker(line []byte, marker string) bool {
+	if !bytes.HasPrefix(line, []byte(marker)) {
+		return false
+	}
+	rest := line[len(marker):]
+	return len(rest) == 0 || rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\r' || rest[0] == '\n'
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocuments(t *testing.T) {
+	tests := []struct {
+		desc string
+		give string
+		want []string
+	}{
+		{
+			desc: "empty",
+			give: "",
+			want: []string{""},
+		},
+		{
+			desc: "single document",
+			give: "a: 1\n",
+			want: []string{"a: 1\n"},
+		},
+		{
+			desc: "leading marker",
+			give: "# comment\n%YAML 1.1\n---\na: 1\n",
+			want: []string{"# comment\n%YAML 1.1\n---\na: 1\n"},
+		},
+		{
+			desc: "trailing marker",
+			give: "a: 1\n---\n",
+			want: []string{"a: 1\n\n"},
+		},
+		{
+			desc: "several documents",
+			give: "a: 1\n---\nb: 2\n--- {c: 3}\n",
+			want: []string{
+				"a: 1\n\n\n\n",
+				"\n---\nb: 2\n\n",
+				"\n\n\n--- {c: 3}\n",
+			},
+		},
+		{
+			desc: "empty documents",
+			give: "---\n# nothing\n---\n---\na: 1\n---\n",
+			want: []string{"\n\n\n---\na: 1\n\n"},
+		},
+		{
+			desc: "explicit null",
+			give: "a: 1\n--- ~\n",
+			want: []string{"a: 1\n\n", "\n--- ~\n"},
+		},
+		{
+			desc: "document end markers",
+			give: "a: 1\n...\nb: 2\n",
+			want: []string{"a: 1\n...\n\n", "\n\nb: 2\n"},
+		},
+		{
+			desc: "markers must start lines",
+			give: "a: |\n  ---\n  text\nb: ---x\n---x: 1\n",
+			want: []string{"a: |\n  ---\n  text\nb: ---x\n---x: 1\n"},
+		},
+		{
+			desc: "only empty documents",
+			give: "---\n---\n",
+			want: []string{"---\n---\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, doc := range Documents([]byte(tt.give)) {
+				got = append(got, string(doc))
+			}
+			assert.Equal(t, tt.want, got, "unexpected documents")
+		})
+	}
+}
+
+func TestMultipleDocuments(t *testing.T) {
+	sources := [][]byte{
+		[]byte("a: {p: 1, q: 1}\n---\na: {q: 2}\nb: [1]\n"),
+		[]byte("---\nb: [2]\n---\n"),
+	}
+	merged, err := YAML(sources, true /* strict */)
+	if assert.NoError(t, err, "merge failed") {
+		assert.Equal(t, "a:\n  p: 1\n  q: 2\nb:\n- 2\n", merged.String(), "unexpected contents")
+	}
+
+	_, err = YAML([][]byte{[]byte("a: 1\n---\na: 1\na: 2\n")}, true /* strict */)
+	assert.Error(t, err, "expected later documents to be decoded strictly")
+}
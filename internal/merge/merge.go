@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 
 	"go.uber.org/config/internal/unreachable"
 
@@ -57,11 +58,32 @@ type (
 // value with the new.
 //
 // Enabling strict mode returns errors in both of the above cases.
+//
+// Sources may contain several documents separated by "---" markers. The
+// documents in a source are merged in order, as though they were separate
+// sources; see Documents for details. Documents may use anchors defined by
+// earlier documents; see Prepare.
 func YAML(sources [][]byte, strict bool) (*bytes.Buffer, error) {
-	var merged interface{}
-	var hasContent bool
-	for _, r := range sources {
-		d := yaml.NewDecoder(bytes.NewReader(r))
+	return NormalizedYAML(sources, strict, nil)
+}
+
+// NormalizedYAML is like YAML, but string mapping keys that are the same after
+// normalization are treated as the same key. Merged mappings keep the
+// highest-priority spelling of each key.
+//
+// In strict mode, spelling a key differently than a lower-priority source
+// does is an error, as is spelling it two ways in a single source. In
+// non-strict mode, the values are merged, in unspecified order if they're in
+// the same source.
+func NormalizedYAML(sources [][]byte, strict bool, normalize func(string) string) (*bytes.Buffer, error) {
+	docs, err := Prepare(sources, false /* v3 */)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode source: %v", err)
+	}
+
+	var values []interface{}
+	for _, doc := range docs {
+		d := yaml.NewDecoder(bytes.NewReader(doc.Bytes))
 		d.SetStrict(strict)
 
 		var contents interface{}
@@ -70,23 +92,21 @@ func YAML(sources [][]byte, strict bool) (*bytes.Buffer, error) {
 			// differently from explicit nils.
 			continue
 		} else if err != nil {
-			return nil, fmt.Errorf("couldn't decode source: %v", err)
-		}
-
-		hasContent = true
-		pair, err := merge(merged, contents, strict)
-		if err != nil {
-			return nil, err // error is already descriptive enough
+			return nil, fmt.Errorf("couldn't decode source: %v", doc.Err(err))
 		}
-		merged = pair
+		values = append(values, doc.Unwrap(contents))
 	}
 
 	buf := &bytes.Buffer{}
-	if !hasContent {
+	if len(values) == 0 {
 		// No sources had any content. To distinguish this from a source with just
 		// an explicit top-level null, return an empty buffer.
 		return buf, nil
 	}
+	merged, err := Values(values, strict, normalize)
+	if err != nil {
+		return nil, err
+	}
 	enc := yaml.NewEncoder(buf)
 	if err := enc.Encode(merged); err != nil {
 		return nil, unreachable.Wrap(fmt.Errorf("couldn't re-serialize merged YAML: %v", err))
@@ -94,7 +114,33 @@ func YAML(sources [][]byte, strict bool) (*bytes.Buffer, error) {
 	return buf, nil
 }
 
-func merge(into, from interface{}, strict bool) (interface{}, error) {
+// Values merges already-decoded YAML documents like NormalizedYAML, which
+// lets callers use a different YAML parser. Documents must be represented as
+// gopkg.in/yaml.v2 represents them when decoding into an interface{}. The
+// normalize function may be nil.
+func Values(docs []interface{}, strict bool, normalize func(string) string) (interface{}, error) {
+	m := merger{strict: strict, normalize: normalize}
+	var merged interface{}
+	for _, contents := range docs {
+		contents, err := m.fold(contents)
+		if err != nil {
+			return nil, err
+		}
+		pair, err := m.merge(merged, contents)
+		if err != nil {
+			return nil, err // error is already descriptive enough
+		}
+		merged = pair
+	}
+	return merged, nil
+}
+
+type merger struct {
+	strict    bool
+	normalize func(string) string // nil unless keys are normalized
+}
+
+func (m merger) merge(into, from interface{}) (interface{}, error) {
 	// It's possible to handle this with a mass of reflection, but we only need
 	// to merge whole YAML files. Since we're always unmarshaling into
 	// interface{}, we only need to handle a few types. This ends up being
@@ -113,32 +159,107 @@ func merge(into, from interface{}, strict bool) (interface{}, error) {
 		return from, nil
 	}
 	if IsMapping(into) && IsMapping(from) {
-		return mergeMapping(into.(mapping), from.(mapping), strict)
+		return m.mergeMapping(into.(mapping), from.(mapping))
 	}
 	// YAML types don't match, so no merge is possible. For backward
 	// compatibility, ignore mismatches unless we're in strict mode and return
 	// the higher-priority value.
-	if !strict {
+	if !m.strict {
 		return from, nil
 	}
 	return nil, fmt.Errorf("can't merge a %s into a %s", describe(from), describe(into))
 }
 
-func mergeMapping(into, from mapping, strict bool) (mapping, error) {
+func (m merger) mergeMapping(into, from mapping) (mapping, error) {
 	merged := make(mapping, len(into))
 	for k, v := range into {
 		merged[k] = v
 	}
 	for k := range from {
-		m, err := merge(merged[k], from[k], strict)
+		if existing, ok := m.find(merged, k); ok && existing != k {
+			if m.strict {
+				return nil, fmt.Errorf("key %q conflicts with key %q from a lower-priority source after normalization", k, existing)
+			}
+			merged[k] = merged[existing]
+			delete(merged, existing)
+		}
+		v, err := m.merge(merged[k], from[k])
 		if err != nil {
 			return nil, err
 		}
-		merged[k] = m
+		merged[k] = v
 	}
 	return merged, nil
 }
 
+// fold merges the keys within each mapping of a single source that are the
+// same after normalization.
+func (m merger) fold(i interface{}) (interface{}, error) {
+	if m.normalize == nil {
+		return i, nil
+	}
+	switch typed := i.(type) {
+	case mapping:
+		keys := make([]interface{}, 0, len(typed))
+		for k := range typed {
+			keys = append(keys, k)
+		}
+		// Sort keys so that non-strict folding is deterministic.
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		folded := make(mapping, len(typed))
+		for _, k := range keys {
+			v, err := m.fold(typed[k])
+			if err != nil {
+				return nil, err
+			}
+			if existing, ok := m.find(folded, k); ok {
+				if m.strict {
+					return nil, fmt.Errorf("keys %q and %q are the same after normalization", existing, k)
+				}
+				if v, err = m.merge(folded[existing], v); err != nil {
+					return nil, err
+				}
+				delete(folded, existing)
+			}
+			folded[k] = v
+		}
+		return folded, nil
+	case sequence:
+		folded := make(sequence, len(typed))
+		for idx, elem := range typed {
+			v, err := m.fold(elem)
+			if err != nil {
+				return nil, err
+			}
+			folded[idx] = v
+		}
+		return folded, nil
+	default:
+		return i, nil
+	}
+}
+
+// find returns the key in a mapping that's the same as k after
+// normalization. Keys that aren't strings are never normalized.
+func (m merger) find(in mapping, k interface{}) (interface{}, bool) {
+	if _, ok := in[k]; ok {
+		return k, true
+	}
+	s, ok := k.(string)
+	if !ok || m.normalize == nil {
+		return nil, false
+	}
+	want := m.normalize(s)
+	for existing := range in {
+		if es, ok := existing.(string); ok && m.normalize(es) == want {
+			return existing, true
+		}
+	}
+	return nil, false
+}
+
 // IsMapping reports whether a type is a mapping in YAML, represented as a
 // map[interface{}]interface{}.
 func IsMapping(i interface{}) bool {
@@ -72,7 +72,7 @@ func unmarshal(t testing.TB, s string) interface{} {
 
 func succeeds(t testing.TB, strict bool, left, right, expect string) {
 	l, r := unmarshal(t, left), unmarshal(t, right)
-	m, err := merge(l, r, strict)
+	m, err := merger{strict: strict}.merge(l, r)
 	require.NoError(t, err, "merge failed")
 
 	actualBytes, err := yaml.Marshal(m)
@@ -85,7 +85,7 @@ func succeeds(t testing.TB, strict bool, left, right, expect string) {
 }
 
 func fails(t testing.TB, strict bool, left, right string) {
-	_, err := merge(unmarshal(t, left), unmarshal(t, right), strict)
+	_, err := merger{strict: strict}.merge(unmarshal(t, left), unmarshal(t, right))
 	assert.Error(t, err, "merge succeeded")
 }
 
@@ -229,3 +229,69 @@ func TestExplicitNil(t *testing.T) {
 	succeeds(t, true, base, override, expect)
 	succeeds(t, false, base, override, expect)
 }
+
+func TestNormalized(t *testing.T) {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.Replace(s, "_", "", -1))
+	}
+
+	tests := []struct {
+		desc    string
+		strict  bool
+		sources []string
+		expect  string
+		err     string
+	}{
+		{
+			desc:    "same spelling",
+			strict:  true,
+			sources: []string{"max_conns: 1", "max_conns: 2"},
+			expect:  "max_conns: 2",
+		},
+		{
+			desc:    "respelled across sources",
+			strict:  true,
+			sources: []string{"max_conns: 1", "maxConns: 2"},
+			err:     `key "maxConns" conflicts with key "max_conns" from a lower-priority source after normalization`,
+		},
+		{
+			desc:    "respelled within source",
+			strict:  true,
+			sources: []string{"a: {max_conns: 1, MaxConns: 2}"},
+			err:     `keys "MaxConns" and "max_conns" are the same after normalization`,
+		},
+		{
+			desc:    "permissive across sources",
+			sources: []string{"limits: {max_conns: 1, other: true}", "Limits: {maxConns: 2}"},
+			expect:  "Limits: {maxConns: 2, other: true}",
+		},
+		{
+			desc:    "permissive within source",
+			sources: []string{"[{a: {x: 1}, A: {y: 2}}]"},
+			expect:  "[{a: {x: 1, y: 2}}]",
+		},
+		{
+			desc:    "non-string keys",
+			strict:  true,
+			sources: []string{"{1: one, true: yes}", "{1: uno}"},
+			expect:  "{1: uno, true: yes}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			sources := make([][]byte, len(tt.sources))
+			for i, s := range tt.sources {
+				sources[i] = []byte(s)
+			}
+			merged, err := NormalizedYAML(sources, tt.strict, normalize)
+			if tt.err != "" {
+				require.Error(t, err, "expected merge to fail")
+				assert.Contains(t, err.Error(), tt.err, "unexpected error")
+				return
+			}
+			require.NoError(t, err, "merge failed")
+			assert.Equal(t, canonicalize(t, tt.expect), canonicalize(t, merged.String()), "unexpected contents")
+		})
+	}
+}
@@ -0,0 +1,607 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package schema validates unmarshalled YAML against a subset of JSON Schema
+// draft 2020-12. The supported keywords are documented on the Schema option
+// in go.uber.org/config; all other keywords are ignored.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// A Violation describes a value that doesn't conform to a schema.
+type Violation struct {
+	Path    []string // location of the offending value
+	Message string
+}
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	root *node
+}
+
+// Parse reads and compiles a JSON Schema.
+func Parse(r io.Reader) (*Schema, error) {
+	dec := json.NewDecoder(r)
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("couldn't decode JSON Schema: %v", err)
+	}
+	c := &compiler{doc: raw, refs: make(map[string]*node)}
+	root, err := c.compile(raw, "#")
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON Schema: %v", err)
+	}
+	return &Schema{root: root}, nil
+}
+
+// Validate checks a value produced by unmarshalling YAML into an
+// interface{}. It returns all violations in a deterministic order.
+func (s *Schema) Validate(value interface{}) []Violation {
+	v := &validator{}
+	v.validate(s.root, []string{}, normalize(value))
+	return v.violations
+}
+
+type node struct {
+	// Boolean schemas accept or reject everything.
+	always *bool
+
+	ref *node
+
+	types    []string
+	enum     []interface{}
+	constVal interface{}
+	hasConst bool
+
+	allOf, anyOf, oneOf []*node
+	not                 *node
+
+	properties           map[string]*node
+	patternProperties    []patternNode
+	additionalProperties *node
+	required             []string
+	minProperties        *int
+	maxProperties        *int
+
+	items       *node
+	prefixItems []*node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+	multipleOf                         *float64
+
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+}
+
+type patternNode struct {
+	pattern *regexp.Regexp
+	schema  *node
+}
+
+type compiler struct {
+	doc  interface{}
+	refs map[string]*node
+}
+
+func (c *compiler) compile(raw interface{}, loc string) (*node, error) {
+	if b, ok := raw.(bool); ok {
+		return &node{always: &b}, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", loc)
+	}
+
+	n := &node{}
+	for kw, val := range m {
+		at := loc + "/" + kw
+		var err error
+		switch kw {
+		case "$ref":
+			n.ref, err = c.resolve(val, at)
+		case "type":
+			n.types, err = stringOrStrings(val, at)
+		case "enum":
+			arr, ok := val.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: must be an array", at)
+			}
+			n.enum = arr
+		case "const":
+			n.constVal, n.hasConst = val, true
+		case "allOf":
+			n.allOf, err = c.compileList(val, at)
+		case "anyOf":
+			n.anyOf, err = c.compileList(val, at)
+		case "oneOf":
+			n.oneOf, err = c.compileList(val, at)
+		case "not":
+			n.not, err = c.compile(val, at)
+		case "properties":
+			n.properties, err = c.compileMap(val, at)
+		case "patternProperties":
+			var props map[string]*node
+			props, err = c.compileMap(val, at)
+			for _, p := range sortedNames(props) {
+				re, rerr := regexp.Compile(p)
+				if rerr != nil {
+					return nil, fmt.Errorf("%s: invalid pattern %q: %v", at, p, rerr)
+				}
+				n.patternProperties = append(n.patternProperties, patternNode{re, props[p]})
+			}
+		case "additionalProperties":
+			n.additionalProperties, err = c.compile(val, at)
+		case "required":
+			n.required, err = stringOrStrings(val, at)
+		case "minProperties":
+			n.minProperties, err = nonNegative(val, at)
+		case "maxProperties":
+			n.maxProperties, err = nonNegative(val, at)
+		case "items":
+			n.items, err = c.compile(val, at)
+		case "prefixItems":
+			n.prefixItems, err = c.compileList(val, at)
+		case "minItems":
+			n.minItems, err = nonNegative(val, at)
+		case "maxItems":
+			n.maxItems, err = nonNegative(val, at)
+		case "uniqueItems":
+			b, ok := val.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a boolean", at)
+			}
+			n.uniqueItems = b
+		case "minimum":
+			n.minimum, err = number(val, at)
+		case "maximum":
+			n.maximum, err = number(val, at)
+		case "exclusiveMinimum":
+			n.exclusiveMinimum, err = number(val, at)
+		case "exclusiveMaximum":
+			n.exclusiveMaximum, err = number(val, at)
+		case "multipleOf":
+			n.multipleOf, err = number(val, at)
+			if err == nil && *n.multipleOf <= 0 {
+				err = fmt.Errorf("%s: must be positive", at)
+			}
+		case "minLength":
+			n.minLength, err = nonNegative(val, at)
+		case "maxLength":
+			n.maxLength, err = nonNegative(val, at)
+		case "pattern":
+			s, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must be a string", at)
+			}
+			n.pattern, err = regexp.Compile(s)
+			if err != nil {
+				err = fmt.Errorf("%s: invalid pattern %q: %v", at, s, err)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func (c *compiler) compileList(raw interface{}, loc string) ([]*node, error) {
+	arr, ok := raw.([]interface{})
+	if !ok || len(arr) == 0 {
+		return nil, fmt.Errorf("%s: must be a non-empty array", loc)
+	}
+	nodes := make([]*node, len(arr))
+	for i, s := range arr {
+		n, err := c.compile(s, loc+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = n
+	}
+	return nodes, nil
+}
+
+func (c *compiler) compileMap(raw interface{}, loc string) (map[string]*node, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: must be an object", loc)
+	}
+	nodes := make(map[string]*node, len(m))
+	for k, s := range m {
+		n, err := c.compile(s, loc+"/"+k)
+		if err != nil {
+			return nil, err
+		}
+		nodes[k] = n
+	}
+	return nodes, nil
+}
+
+// resolve compiles the target of a local reference. To support recursive
+// schemas, it registers a placeholder before compiling the target and fills
+// it in afterwards.
+func (c *compiler) resolve(raw interface{}, loc string) (*node, error) {
+	ref, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a string", loc)
+	}
+	if n, ok := c.refs[ref]; ok {
+		return n, nil
+	}
+	if ref != "#" && !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("%s: only local references are supported, got %q", loc, ref)
+	}
+
+	target := c.doc
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, ok := target.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: can't resolve reference %q", loc, ref)
+		}
+		if target, ok = m[token]; !ok {
+			return nil, fmt.Errorf("%s: can't resolve reference %q", loc, ref)
+		}
+	}
+
+	placeholder := &node{}
+	c.refs[ref] = placeholder
+	n, err := c.compile(target, ref)
+	if err != nil {
+		return nil, err
+	}
+	*placeholder = *n
+	return placeholder, nil
+}
+
+func stringOrStrings(raw interface{}, loc string) ([]string, error) {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i, s := range v {
+			str, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: must contain only strings", loc)
+			}
+			strs[i] = str
+		}
+		return strs, nil
+	default:
+		return nil, fmt.Errorf("%s: must be a string or an array of strings", loc)
+	}
+}
+
+func number(raw interface{}, loc string) (*float64, error) {
+	f, ok := raw.(float64)
+	if !ok {
+		return nil, fmt.Errorf("%s: must be a number", loc)
+	}
+	return &f, nil
+}
+
+func nonNegative(raw interface{}, loc string) (*int, error) {
+	f, ok := raw.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%s: must be a non-negative integer", loc)
+	}
+	i := int(f)
+	return &i, nil
+}
+
+type validator struct {
+	violations []Violation
+}
+
+func (v *validator) fail(path []string, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// matches reports whether a value conforms to a schema without recording any
+// violations.
+func matches(n *node, path []string, value interface{}) bool {
+	v := &validator{}
+	v.validate(n, path, value)
+	return len(v.violations) == 0
+}
+
+// validate checks a normalized value against a schema.
+func (v *validator) validate(n *node, path []string, value interface{}) {
+	if n.always != nil {
+		if !*n.always {
+			v.fail(path, "no value is allowed")
+		}
+		return
+	}
+	if n.ref != nil {
+		v.validate(n.ref, path, value)
+	}
+
+	if len(n.types) > 0 && !hasType(n.types, value) {
+		v.fail(path, "expected %s, got %s", strings.Join(n.types, " or "), typeOf(value))
+		return
+	}
+	if n.enum != nil {
+		found := false
+		for _, e := range n.enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must be one of %s", describe(n.enum))
+		}
+	}
+	if n.hasConst && !reflect.DeepEqual(n.constVal, value) {
+		v.fail(path, "must be %s", describe(n.constVal))
+	}
+
+	for _, sub := range n.allOf {
+		v.validate(sub, path, value)
+	}
+	if n.anyOf != nil {
+		found := false
+		for _, sub := range n.anyOf {
+			if matches(sub, path, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must match at least one schema in anyOf")
+		}
+	}
+	if n.oneOf != nil {
+		count := 0
+		for _, sub := range n.oneOf {
+			if matches(sub, path, value) {
+				count++
+			}
+		}
+		if count != 1 {
+			v.fail(path, "must match exactly one schema in oneOf, matched %d", count)
+		}
+	}
+	if n.not != nil && matches(n.not, path, value) {
+		v.fail(path, "must not match the schema in not")
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(n, path, typed)
+	case []interface{}:
+		v.validateArray(n, path, typed)
+	case float64:
+		v.validateNumber(n, path, typed)
+	case string:
+		v.validateString(n, path, typed)
+	}
+}
+
+func (v *validator) validateObject(n *node, path []string, obj map[string]interface{}) {
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			v.fail(extend(path, name), "property is required")
+		}
+	}
+	if n.minProperties != nil && len(obj) < *n.minProperties {
+		v.fail(path, "must have at least %d properties, got %d", *n.minProperties, len(obj))
+	}
+	if n.maxProperties != nil && len(obj) > *n.maxProperties {
+		v.fail(path, "must have at most %d properties, got %d", *n.maxProperties, len(obj))
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		child := extend(path, name)
+		matched := false
+		if sub, ok := n.properties[name]; ok {
+			matched = true
+			v.validate(sub, child, obj[name])
+		}
+		for _, pp := range n.patternProperties {
+			if pp.pattern.MatchString(name) {
+				matched = true
+				v.validate(pp.schema, child, obj[name])
+			}
+		}
+		if !matched && n.additionalProperties != nil {
+			if a := n.additionalProperties.always; a != nil && !*a {
+				v.fail(child, "property is not allowed")
+				continue
+			}
+			v.validate(n.additionalProperties, child, obj[name])
+		}
+	}
+}
+
+func (v *validator) validateArray(n *node, path []string, arr []interface{}) {
+	if n.minItems != nil && len(arr) < *n.minItems {
+		v.fail(path, "must have at least %d items, got %d", *n.minItems, len(arr))
+	}
+	if n.maxItems != nil && len(arr) > *n.maxItems {
+		v.fail(path, "must have at most %d items, got %d", *n.maxItems, len(arr))
+	}
+	if n.uniqueItems {
+	outer:
+		for i := range arr {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(arr[i], arr[j]) {
+					v.fail(extend(path, strconv.Itoa(i)), "duplicates item %d", j)
+					break outer
+				}
+			}
+		}
+	}
+	for i, item := range arr {
+		child := extend(path, strconv.Itoa(i))
+		if i < len(n.prefixItems) {
+			v.validate(n.prefixItems[i], child, item)
+		} else if n.items != nil {
+			v.validate(n.items, child, item)
+		}
+	}
+}
+
+func (v *validator) validateNumber(n *node, path []string, f float64) {
+	if n.minimum != nil && f < *n.minimum {
+		v.fail(path, "must be at least %v, got %v", *n.minimum, f)
+	}
+	if n.maximum != nil && f > *n.maximum {
+		v.fail(path, "must be at most %v, got %v", *n.maximum, f)
+	}
+	if n.exclusiveMinimum != nil && f <= *n.exclusiveMinimum {
+		v.fail(path, "must be greater than %v, got %v", *n.exclusiveMinimum, f)
+	}
+	if n.exclusiveMaximum != nil && f >= *n.exclusiveMaximum {
+		v.fail(path, "must be less than %v, got %v", *n.exclusiveMaximum, f)
+	}
+	if n.multipleOf != nil {
+		if q := f / *n.multipleOf; q != math.Trunc(q) {
+			v.fail(path, "must be a multiple of %v, got %v", *n.multipleOf, f)
+		}
+	}
+}
+
+func (v *validator) validateString(n *node, path []string, s string) {
+	length := utf8.RuneCountInString(s)
+	if n.minLength != nil && length < *n.minLength {
+		v.fail(path, "must be at least %d characters, got %d", *n.minLength, length)
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		v.fail(path, "must be at most %d characters, got %d", *n.maxLength, length)
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		v.fail(path, "must match pattern %q", n.pattern.String())
+	}
+}
+
+// normalize converts unmarshalled YAML into the representation produced by
+// encoding/json, so that values can be compared with the contents of schemas.
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			m[fmt.Sprint(k)] = normalize(elem)
+		}
+		return m
+	case map[string]interface{}:
+		return v
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			s[i] = normalize(elem)
+		}
+		return s
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+func hasType(types []string, value interface{}) bool {
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func describe(value interface{}) string {
+	bs, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(bs)
+}
+
+func sortedNames(m map[string]*node) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func extend(path []string, segment string) []string {
+	extended := make([]string, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, segment)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func mustParse(t testing.TB, s string) *Schema {
+	schema, err := Parse(strings.NewReader(s))
+	require.NoError(t, err, "couldn't parse schema")
+	return schema
+}
+
+func violations(t testing.TB, schema *Schema, doc string) []string {
+	var value interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &value), "couldn't unmarshal YAML")
+	var msgs []string
+	for _, v := range schema.Validate(value) {
+		msgs = append(msgs, strings.Join(v.Path, ".")+": "+v.Message)
+	}
+	return msgs
+}
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		desc   string
+		schema string
+		doc    string
+		want   []string
+	}{
+		{"true", `true`, `anything`, nil},
+		{"false", `false`, `anything`, []string{": no value is allowed"}},
+		{"type", `{"type": "string"}`, `1`, []string{": expected string, got integer"}},
+		{"type list", `{"type": ["string", "null"]}`, `~`, nil},
+		{"integer is a number", `{"type": "number"}`, `1`, nil},
+		{"whole float is an integer", `{"type": "integer"}`, `1.0`, nil},
+		{"fraction isn't an integer", `{"type": "integer"}`, `1.5`, []string{": expected integer, got number"}},
+		{"boolean", `{"type": "boolean"}`, `yes`, nil},
+		{"enum", `{"enum": ["a", 1]}`, `b`, []string{`: must be one of ["a",1]`}},
+		{"enum number", `{"enum": ["a", 1]}`, `1`, nil},
+		{"const", `{"const": {"a": [1]}}`, `{a: [2]}`, []string{`: must be {"a":[1]}`}},
+		{"allOf", `{"allOf": [{"minimum": 2}, {"maximum": 0}]}`, `1`, []string{
+			": must be at least 2, got 1",
+			": must be at most 0, got 1",
+		}},
+		{"anyOf", `{"anyOf": [{"type": "string"}, {"type": "array"}]}`, `1`, []string{": must match at least one schema in anyOf"}},
+		{"oneOf", `{"oneOf": [{"type": "integer"}, {"type": "number"}]}`, `1`, []string{": must match exactly one schema in oneOf, matched 2"}},
+		{"not", `{"not": {"type": "null"}}`, `~`, []string{": must not match the schema in not"}},
+		{"object", `{
+			"properties": {"port": {"type": "integer"}},
+			"patternProperties": {"^x-": {"type": "string"}},
+			"additionalProperties": false,
+			"required": ["host", "port"],
+			"minProperties": 4
+		}`, `{port: "80", x-foo: 1, other: true}`, []string{
+			"host: property is required",
+			": must have at least 4 properties, got 3",
+			"other: property is not allowed",
+			"port: expected integer, got string",
+			"x-foo: expected string, got integer",
+		}},
+		{"additionalProperties schema", `{"additionalProperties": {"type": "integer"}, "maxProperties": 1}`, `{a: 1, b: c}`, []string{
+			": must have at most 1 properties, got 2",
+			"b: expected integer, got string",
+		}},
+		{"array", `{
+			"prefixItems": [{"type": "string"}],
+			"items": {"type": "integer"},
+			"minItems": 4,
+			"maxItems": 2,
+			"uniqueItems": true
+		}`, `[1, 2, 2]`, []string{
+			": must have at least 4 items, got 3",
+			": must have at most 2 items, got 3",
+			"2: duplicates item 1",
+			"0: expected string, got integer",
+		}},
+		{"number", `{"exclusiveMinimum": 1, "exclusiveMaximum": 1, "multipleOf": 0.5}`, `1.25`, []string{
+			": must be less than 1, got 1.25",
+			": must be a multiple of 0.5, got 1.25",
+		}},
+		{"number bounds", `{"exclusiveMinimum": 2}`, `2`, []string{": must be greater than 2, got 2"}},
+		{"string", `{"minLength": 3, "maxLength": 1, "pattern": "^[a-z]+$"}`, `"Hé"`, []string{
+			": must be at least 3 characters, got 2",
+			": must be at most 1 characters, got 2",
+			`: must match pattern "^[a-z]+$"`,
+		}},
+		{"ref", `{
+			"$defs": {"port": {"type": "integer", "maximum": 65535}},
+			"properties": {"http": {"$ref": "#/$defs/port"}, "grpc": {"$ref": "#/$defs/port"}}
+		}`, `{http: 80, grpc: 70000}`, []string{"grpc: must be at most 65535, got 70000"}},
+		{"recursive ref", `{
+			"definitions": {"tree": {"type": "object", "additionalProperties": {"$ref": "#/definitions/tree"}}}, 
+			"$ref": "#/definitions/tree"
+		}`, `{a: {b: {c: 1}}}`, []string{"a.b.c: expected object, got integer"}},
+		{"ignored keywords", `{"title": "x", "description": "y", "format": "email"}`, `1`, nil},
+		{"non-string keys", `{"properties": {"1": {"type": "string"}}}`, `{1: 1}`, []string{"1: expected string, got integer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, violations(t, mustParse(t, tt.schema), tt.doc))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		schema string
+		err    string
+	}{
+		{"not JSON", `{`, "couldn't decode JSON Schema"},
+		{"not a schema", `1`, "#: schema must be an object or a boolean"},
+		{"bad type", `{"type": 1}`, "#/type: must be a string or an array of strings"},
+		{"bad type list", `{"type": [1]}`, "#/type: must contain only strings"},
+		{"bad enum", `{"enum": 1}`, "#/enum: must be an array"},
+		{"empty allOf", `{"allOf": []}`, "#/allOf: must be a non-empty array"},
+		{"bad nested", `{"properties": {"a": 1}}`, "#/properties/a: schema must be an object or a boolean"},
+		{"bad properties", `{"properties": 1}`, "#/properties: must be an object"},
+		{"bad pattern", `{"pattern": "("}`, "#/pattern: invalid pattern"},
+		{"non-string pattern", `{"pattern": 1}`, "#/pattern: must be a string"},
+		{"bad patternProperties", `{"patternProperties": {"(": true}}`, "#/patternProperties: invalid pattern"},
+		{"bad minimum", `{"minimum": "1"}`, "#/minimum: must be a number"},
+		{"bad multipleOf", `{"multipleOf": 0}`, "#/multipleOf: must be positive"},
+		{"bad length", `{"minLength": 1.5}`, "#/minLength: must be a non-negative integer"},
+		{"bad uniqueItems", `{"uniqueItems": 1}`, "#/uniqueItems: must be a boolean"},
+		{"remote ref", `{"$ref": "http://example.com/schema.json"}`, "only local references are supported"},
+		{"missing ref", `{"$ref": "#/$defs/missing"}`, `can't resolve reference "#/$defs/missing"`},
+		{"bad ref", `{"$ref": 1}`, "#/$ref: must be a string"},
+		{"bad ref target", `{"$defs": {"a": 1}, "$ref": "#/$defs/a"}`, "#/$defs/a: schema must be an object or a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.schema))
+			require.Error(t, err, "expected parsing to fail")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error")
+		})
+	}
+}
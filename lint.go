@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/config/internal/merge"
+	"go.uber.org/multierr"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var (
+	// Pattern from http://yaml.org/type/int.html. Both gopkg.in/yaml.v2 and
+	// gopkg.in/yaml.v3 read these scalars as octal integers.
+	_octalPattern = regexp.MustCompile(`^[-+]?0[0-7_]+$`)
+
+	// YAML 1.1 Booleans other than true and false, spelled as gopkg.in/yaml.v2
+	// accepts them.
+	_ambiguousBools = map[string]bool{
+		"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+		"on": true, "On": true, "ON": true,
+		"n": false, "N": false, "no": false, "No": false, "NO": false,
+		"off": false, "Off": false, "OFF": false,
+	}
+)
+
+// An Ambiguity is an unquoted scalar that the YAML parser reads as something
+// other than the string most authors expect. See Lint.
+type Ambiguity struct {
+	// Path is the full, period-separated configuration path of the scalar.
+	// For mapping keys, it's the path of the key itself.
+	Path string
+	// Value is the scalar as written in the source.
+	Value string
+	// Meaning describes how the parser reads the scalar (for example, "the
+	// Boolean true" or "the integer 493").
+	Meaning string
+	// Source, Line, and Column locate the scalar, as in ValidationError.
+	Source string
+	Line   int
+	Column int
+}
+
+func (a Ambiguity) String() string {
+	msg := a.message()
+	if a.Path != "" {
+		msg = a.Path + ": " + msg
+	}
+	if a.Source != "" {
+		msg = fmt.Sprintf("%s (from %s:%d:%d)", msg, a.Source, a.Line, a.Column)
+	}
+	return msg
+}
+
+func (a Ambiguity) message() string {
+	return fmt.Sprintf("unquoted %s is read as %s", a.Value, a.Meaning)
+}
+
+// Lint scans each source before merging for unquoted scalars that the YAML
+// parser reads as something other than a string, contrary to most authors'
+// expectations. With either parser, the null ~ and octal-looking integers
+// like 0755 are reported. By default, sources are parsed with
+// gopkg.in/yaml.v2, which follows YAML 1.1, so Booleans other than true and
+// false (yes, no, on, off, and their variants) are reported too; with the
+// YAMLv3 option, they stay strings. Sexagesimal numbers like 1:30 aren't
+// reported, since neither parser reads them as anything but a string.
+// Both keys and values are checked.
+//
+// NewYAML calls f, which may be nil, with each ambiguity it finds. In strict
+// mode, any ambiguity also fails construction; each one is reported as a
+// *ValidationError. In permissive mode, ambiguities are only passed to f.
+// Quoting the scalar, or tagging it explicitly (as in !!bool yes), resolves
+// the ambiguity.
+func Lint(f func(Ambiguity)) YAMLOption {
+	return optionFunc(func(c *config) {
+		c.lint = true
+		if f != nil {
+			c.lints = append(c.lints, f)
+		}
+	})
+}
+
+// lint scans the sources for scalars that the parser (yaml.v3 if v3 is set,
+// and yaml.v2 otherwise) reads ambiguously, calling the supplied functions
+// with each one. In strict mode, it returns them as errors. Sources that
+// can't be parsed are skipped, since merging reports them.
+func lint(sources [][]byte, names []string, strict, v3 bool, fs []func(Ambiguity)) error {
+	// Merging reports undefined aliases, so don't check them here.
+	docs, _ := merge.Prepare(sources, v3)
+	var errs error
+	for _, doc := range docs {
+		var n yamlv3.Node
+		if err := yamlv3.Unmarshal(doc.Bytes, &n); err != nil {
+			continue
+		}
+		lintNode(doc.UnwrapNode(&n), nil, v3, func(a Ambiguity) {
+			a.Source = names[doc.Source]
+			for _, f := range fs {
+				f(a)
+			}
+			if strict {
+				errs = multierr.Append(errs, &ValidationError{
+					Path:   a.Path,
+					Source: a.Source,
+					Line:   a.Line,
+					Column: a.Column,
+					Err:    errors.New(a.message() + "; quote it to keep it a string"),
+				})
+			}
+		})
+	}
+	return errs
+}
+
+func lintNode(n *yamlv3.Node, path []string, v3 bool, report func(Ambiguity)) {
+	if n == nil {
+		return
+	}
+	switch n.Kind {
+	case yamlv3.DocumentNode:
+		for _, c := range n.Content {
+			lintNode(c, path, v3, report)
+		}
+	case yamlv3.SequenceNode:
+		for i, c := range n.Content {
+			lintNode(c, extend(path, strconv.Itoa(i)), v3, report)
+		}
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			p := extend(path, k.Value)
+			lintNode(k, p, v3, report)
+			lintNode(v, p, v3, report)
+		}
+	case yamlv3.ScalarNode:
+		if n.Style != 0 {
+			// Quoted, tagged, or block scalars are unambiguous.
+			return
+		}
+		if meaning, ok := ambiguousMeaning(n.Value, v3); ok {
+			report(Ambiguity{
+				Path:    joinPath(path),
+				Value:   n.Value,
+				Meaning: meaning,
+				Line:    n.Line,
+				Column:  n.Column,
+			})
+		}
+	}
+}
+
+// ambiguousMeaning describes how the parser reads a plain scalar, if that's
+// likely to surprise the author. The v3 flag selects gopkg.in/yaml.v3's
+// interpretation over gopkg.in/yaml.v2's.
+func ambiguousMeaning(s string, v3 bool) (string, bool) {
+	if !v3 {
+		if b, ok := _ambiguousBools[s]; ok {
+			return fmt.Sprintf("the Boolean %t", b), true
+		}
+	}
+	if s == "~" {
+		return "null", true
+	}
+	if _octalPattern.MatchString(s) {
+		if i, err := strconv.ParseInt(strings.ReplaceAll(s, "_", ""), 8, 64); err == nil {
+			return fmt.Sprintf("the integer %d", i), true
+		}
+	}
+	return "", false
+}
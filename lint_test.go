@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestLint(t *testing.T) {
+	const base = `
+country: no
+mode: 0755
+duration: 1:30
+nothing: ~
+quoted: "yes"
+tagged: !!bool on
+flag: true
+count: 10
+hosts:
+  - h1
+  - off
+`
+	const override = `
+on:
+  push: [main]
+`
+
+	var found []Ambiguity
+	p, err := NewYAML(
+		Source(strings.NewReader(base)),
+		Source(strings.NewReader(override)),
+		Lint(func(a Ambiguity) { found = append(found, a) }),
+		Permissive(),
+	)
+	require.NoError(t, err, "lint shouldn't fail construction in permissive mode")
+	assert.Equal(t, false, p.Get("country").Value(), "lint shouldn't change values")
+
+	assert.Equal(t, []Ambiguity{
+		{Path: "country", Value: "no", Meaning: "the Boolean false", Source: "source 1", Line: 2, Column: 10},
+		{Path: "mode", Value: "0755", Meaning: "the integer 493", Source: "source 1", Line: 3, Column: 7},
+		{Path: "nothing", Value: "~", Meaning: "null", Source: "source 1", Line: 5, Column: 10},
+		{Path: "hosts.1", Value: "off", Meaning: "the Boolean false", Source: "source 1", Line: 12, Column: 5},
+		{Path: "on", Value: "on", Meaning: "the Boolean true", Source: "source 2", Line: 2, Column: 1},
+	}, found, "unexpected ambiguities")
+	assert.Equal(t, "1:30", p.Get("duration").Value(), "sexagesimal-looking scalars should stay strings")
+	assert.Equal(t,
+		"mode: unquoted 0755 is read as the integer 493 (from source 1:3:7)",
+		found[1].String(),
+		"unexpected string representation",
+	)
+}
+
+func TestLintStrict(t *testing.T) {
+	t.Run("ambiguous", func(t *testing.T) {
+		_, err := NewYAML(
+			Source(strings.NewReader("a: yes\n---\nb: 010\n")),
+			Lint(nil),
+		)
+		require.Error(t, err, "expected ambiguities to fail construction")
+		errs := multierr.Errors(err)
+		require.Len(t, errs, 2, "expected an error per ambiguity")
+		assert.Equal(t,
+			"a: unquoted yes is read as the Boolean true; quote it to keep it a string (from source 1:1:4)",
+			errs[0].Error(),
+			"unexpected first error",
+		)
+		verr, ok := errs[1].(*ValidationError)
+		require.True(t, ok, "expected a ValidationError, got %T", errs[1])
+		assert.Equal(t, "b", verr.Path, "unexpected path")
+		assert.Equal(t, 3, verr.Line, "unexpected line")
+		assert.Equal(t, 4, verr.Column, "unexpected column")
+	})
+
+	t.Run("unambiguous", func(t *testing.T) {
+		p, err := NewYAML(
+			Source(strings.NewReader("a: 'yes'\nb: 10\nc: 1.5\nd: 0x1F\ne: true\nf: 12:30 pm\n")),
+			Lint(nil),
+		)
+		require.NoError(t, err, "unexpected lint failure")
+		assert.Equal(t, "yes", p.Get("a").Value(), "unexpected value")
+	})
+
+	t.Run("YAMLv3", func(t *testing.T) {
+		var found []Ambiguity
+		p, err := NewYAML(
+			Source(strings.NewReader("a: yes\nb: ~\nc: 1:30\nd: 0755\n")),
+			YAMLv3(),
+			Lint(func(a Ambiguity) { found = append(found, a) }),
+			Permissive(),
+		)
+		require.NoError(t, err, "lint shouldn't fail construction in permissive mode")
+		assert.Equal(t, "yes", p.Get("a").Value(), "unexpected value")
+		assert.Equal(t, 493, p.Get("d").Value(), "unexpected value")
+		assert.True(t, p.Get("b").HasValue(), "expected ~ to be present")
+		assert.Nil(t, p.Get("b").Value(), "expected ~ to be null")
+		require.Len(t, found, 2, "expected only the null and octal integer to be ambiguous")
+		assert.Equal(t, "b: unquoted ~ is read as null (from source 1:2:4)", found[0].String(), "unexpected ambiguity")
+		assert.Equal(t, "d: unquoted 0755 is read as the integer 493 (from source 1:4:4)", found[1].String(), "unexpected ambiguity")
+	})
+}
+
+func TestAmbiguousMeaning(t *testing.T) {
+	tests := []struct {
+		give    string
+		v3      bool
+		meaning string
+		ok      bool
+	}{
+		{give: "Yes", meaning: "the Boolean true", ok: true},
+		{give: "OFF", meaning: "the Boolean false", ok: true},
+		{give: "n", meaning: "the Boolean false", ok: true},
+		{give: "~", meaning: "null", ok: true},
+		{give: "Yes", v3: true},
+		{give: "~", v3: true, meaning: "null", ok: true},
+		{give: "true"},
+		{give: "-0644", meaning: "the integer -420", ok: true},
+		{give: "-0644", v3: true, meaning: "the integer -420", ok: true},
+		{give: "0"},
+		{give: "08"},
+		{give: "1:30"},
+		{give: "190:20:30"},
+		{give: "10"},
+		{give: "null"},
+	}
+
+	for _, tt := range tests {
+		meaning, ok := ambiguousMeaning(tt.give, tt.v3)
+		assert.Equal(t, tt.ok, ok, "unexpected ambiguity for %q (v3: %v)", tt.give, tt.v3)
+		assert.Equal(t, tt.meaning, meaning, "unexpected meaning for %q (v3: %v)", tt.give, tt.v3)
+	}
+}
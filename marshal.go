@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// _redacted replaces the contents of redacted values in marshalled
+// configuration.
+const _redacted = "<redacted>"
+
+// A Format is a serialization format for configuration.
+type Format int
+
+const (
+	// FormatYAML serializes configuration as YAML.
+	FormatYAML Format = iota + 1
+	// FormatJSON serializes configuration as indented JSON. Since JSON only
+	// allows string keys, mapping keys are converted to their string forms.
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatYAML:
+		return "YAML"
+	case FormatJSON:
+		return "JSON"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// Redacted marks types whose values are secret. Marshal replaces the values
+// of struct fields with Redacted types, as identified by the RedactFields
+// option.
+type Redacted interface {
+	Redacted()
+}
+
+// A MarshalOption alters the default behavior of Value.Marshal.
+type MarshalOption interface {
+	apply(*marshaler)
+}
+
+type marshalOptionFunc func(*marshaler)
+
+func (f marshalOptionFunc) apply(m *marshaler) { f(m) }
+
+// Redact replaces the contents of values whose paths match any of the
+// supplied patterns. Patterns are relative to the value being marshalled and
+// use the same syntax as Value.Query, so a "*" segment matches any single
+// mapping key or sequence index and a "**" segment matches any number of
+// segments. For example, "*.password" matches "db.password" but not
+// "password" or "services.db.password", while "**.password" matches all
+// three.
+func Redact(patterns ...string) MarshalOption {
+	return marshalOptionFunc(func(m *marshaler) {
+		for _, p := range patterns {
+			m.patterns = append(m.patterns, p)
+		}
+	})
+}
+
+// RedactFields redacts values that would populate fields of a Redacted type.
+// It inspects the type of the supplied struct (or pointer to a struct),
+// which should be the type the marshalled value is populated into, and maps
+// fields to paths using the same yaml struct tags as Populate. Fields in
+// nested structs, slices, arrays, and map values are also inspected.
+func RedactFields(target interface{}) MarshalOption {
+	return marshalOptionFunc(func(m *marshaler) {
+		m.patterns = append(m.patterns, redactedPaths(reflect.TypeOf(target))...)
+	})
+}
+
+// Select marshals only the value at key, which is relative to the value being
+// marshalled. Redaction patterns still apply relative to the value being
+// marshalled, so callers can serve part of the configuration while redacting
+// it by its full paths.
+func Select(key string) MarshalOption {
+	return marshalOptionFunc(func(m *marshaler) {
+		m.selected = key
+	})
+}
+
+type marshaler struct {
+	patterns []string
+	selected string
+}
+
+// Marshal serializes the value in the supplied format, sorting mapping keys.
+// Missing values, including out-of-range sequence indexes, are serialized as
+// null. By default, no values are redacted;
+// use the Redact and RedactFields options to keep secrets out of logs and
+// debugging output.
+func (v Value) Marshal(f Format, opts ...MarshalOption) ([]byte, error) {
+	m := &marshaler{}
+	for _, o := range opts {
+		o.apply(m)
+	}
+
+	var contents interface{}
+	// Populate rejects out-of-range sequence indexes, but they're missing
+	// values like any other.
+	if v.HasValue() {
+		if err := v.Populate(&contents); err != nil {
+			return nil, err
+		}
+	}
+	contents = m.redact(contents, v.provider.normalize)
+	if m.selected != Root {
+		for _, segment := range splitKey(m.selected) {
+			// Missing values are serialized as null, just like Get(key).Marshal.
+			contents, _ = child(contents, segment, v.provider.normalize)
+		}
+	}
+
+	switch f {
+	case FormatYAML:
+		return yaml.Marshal(contents)
+	case FormatJSON:
+		j, err := toJSON(contents)
+		if err != nil {
+			return nil, err
+		}
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(j); err != nil {
+			return nil, fmt.Errorf("can't represent configuration as JSON: %v", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown format %v", f)
+	}
+}
+
+// redact returns a copy of i with redacted values replaced. Since i was
+// produced by Populate, it's safe to modify in place.
+func (m *marshaler) redact(i interface{}, normalize func(string) string) interface{} {
+	if len(m.patterns) == 0 {
+		return i
+	}
+	matched := make(map[string]bool)
+	for _, p := range m.patterns {
+		query(i, p, normalize, func(path []string, _ interface{}) {
+			matched[joinPath(path)] = true
+		})
+	}
+	return replaceMatches(nil, i, matched)
+}
+
+// replaceMatches replaces the values whose joined paths are in matched.
+func replaceMatches(path []string, i interface{}, matched map[string]bool) interface{} {
+	if matched[joinPath(path)] {
+		return _redacted
+	}
+	switch typed := i.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range typed {
+			typed[k] = replaceMatches(extend(path, fmt.Sprint(k)), v, matched)
+		}
+	case []interface{}:
+		for idx, v := range typed {
+			typed[idx] = replaceMatches(extend(path, strconv.Itoa(idx)), v, matched)
+		}
+	}
+	return i
+}
+
+// toJSON converts YAML mappings, which may have keys of any scalar type, into
+// string-keyed maps.
+func toJSON(i interface{}) (interface{}, error) {
+	switch typed := i.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			key := fmt.Sprint(k)
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("can't represent mapping as JSON: duplicate key %q", key)
+			}
+			converted, err := toJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = converted
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(typed))
+		for idx, v := range typed {
+			converted, err := toJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			s[idx] = converted
+		}
+		return s, nil
+	default:
+		return i, nil
+	}
+}
+
+var _redactedType = reflect.TypeOf((*Redacted)(nil)).Elem()
+
+// redactedPaths returns patterns matching the paths of all fields of a
+// Redacted type.
+func redactedPaths(t reflect.Type) []string {
+	var paths []string
+	walkFields(t, nil, make(map[reflect.Type]bool), func(path []string, t reflect.Type) bool {
+		if t.Implements(_redactedType) || reflect.PtrTo(t).Implements(_redactedType) {
+			paths = append(paths, joinPath(path))
+			return false
+		}
+		return true
+	})
+	return paths
+}
+
+// walkFields visits the types reachable from t, along with the paths at
+// which they appear in YAML. Struct fields are named using yaml.v2's rules,
+// and elements of slices, arrays, and maps are represented by a "*" segment.
+// If visit returns false, walkFields doesn't descend further.
+func walkFields(t reflect.Type, path []string, active map[reflect.Type]bool, visit func([]string, reflect.Type) bool) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !visit(path, t) {
+		return
+	}
+	// Guard against infinite recursion on self-referential types.
+	if active[t] {
+		return
+	}
+	active[t] = true
+	defer delete(active, t)
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		walkFields(t.Elem(), extend(path, _wildcard), active, visit)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, inline, ok := yamlFieldName(f)
+			if !ok {
+				continue
+			}
+			if inline {
+				walkFields(f.Type, path, active, visit)
+				continue
+			}
+			walkFields(f.Type, extend(path, name), active, visit)
+		}
+	}
+}
+
+// yamlFieldName returns the key gopkg.in/yaml.v2 uses for a struct field,
+// whether the field is inlined, and whether the field is serialized at all.
+func yamlFieldName(f reflect.StructField) (name string, inline bool, ok bool) {
+	if f.PkgPath != "" && !f.Anonymous {
+		return "", false, false // unexported
+	}
+	tag := f.Tag.Get("yaml")
+	if tag == "" && !strings.Contains(string(f.Tag), ":") {
+		tag = string(f.Tag)
+	}
+	if tag == "-" {
+		return "", false, false
+	}
+	fields := strings.Split(tag, ",")
+	for _, flag := range fields[1:] {
+		if flag == "inline" {
+			return "", true, true
+		}
+	}
+	if f.PkgPath != "" {
+		return "", false, false // unexported embedded struct, not inlined
+	}
+	if fields[0] != "" {
+		return fields[0], false, true
+	}
+	return strings.ToLower(f.Name), false, true
+}
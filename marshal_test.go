@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type secret string
+
+func (secret) Redacted() {}
+
+type secretPtr struct{ Value string }
+
+func (*secretPtr) Redacted() {}
+
+func TestMarshal(t *testing.T) {
+	p := mustYAML(t, `
+zeta: last
+alpha: {password: hunter2, user: admin}
+services:
+  - {name: api, token: abc}
+1: one
+`)
+
+	t.Run("YAML", func(t *testing.T) {
+		out, err := p.Get(Root).Marshal(FormatYAML)
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, `1: one
+alpha:
+  password: hunter2
+  user: admin
+services:
+- name: api
+  token: abc
+zeta: last
+`, string(out), "unexpected YAML")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		out, err := p.Get("alpha").Marshal(FormatJSON)
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, "{\n  \"password\": \"hunter2\",\n  \"user\": \"admin\"\n}\n", string(out), "unexpected JSON")
+	})
+
+	t.Run("redacted paths", func(t *testing.T) {
+		out, err := p.Get(Root).Marshal(FormatJSON, Redact("*.password", "**.token"))
+		require.NoError(t, err, "marshal failed")
+		assert.JSONEq(t, `{
+			"1": "one",
+			"alpha": {"password": "<redacted>", "user": "admin"},
+			"services": [{"name": "api", "token": "<redacted>"}],
+			"zeta": "last"
+		}`, string(out), "unexpected JSON")
+		assert.Contains(t, string(out), `"<redacted>"`, "expected HTML characters to be left unescaped")
+	})
+
+	t.Run("redacting root", func(t *testing.T) {
+		out, err := p.Get("alpha.password").Marshal(FormatYAML, Redact(Root))
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, "<redacted>\n", string(out), "unexpected YAML")
+	})
+
+	t.Run("selected", func(t *testing.T) {
+		out, err := p.Get(Root).Marshal(FormatYAML, Redact("alpha.password"), Select("alpha"))
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, "password: <redacted>\nuser: admin\n", string(out), "unexpected YAML")
+
+		out, err = p.Get(Root).Marshal(FormatYAML, Select("services.0.name"))
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, "api\n", string(out), "unexpected YAML")
+
+		out, err = p.Get(Root).Marshal(FormatYAML, Select("not_there"))
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, "null\n", string(out), "unexpected YAML")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		out, err := p.Get("not_there").Marshal(FormatJSON)
+		require.NoError(t, err, "marshal failed")
+		assert.Equal(t, "null\n", string(out), "unexpected JSON")
+
+		out, err = p.Get("services.5").Marshal(FormatYAML)
+		require.NoError(t, err, "marshal failed for out-of-range index")
+		assert.Equal(t, "null\n", string(out), "unexpected YAML")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := p.Get(Root).Marshal(Format(42))
+		assert.EqualError(t, err, "unknown format Format(42)")
+	})
+}
+
+func TestMarshalJSONErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		source string
+		err    string
+	}{
+		{"duplicate keys", `{1: int, "1": string}`, `duplicate key "1"`},
+		{"NaN", `{nan: .nan}`, "can't represent configuration as JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p, err := NewYAML(Permissive(), Source(strings.NewReader(tt.source)))
+			require.NoError(t, err, "couldn't construct provider")
+			_, err = p.Get(Root).Marshal(FormatJSON)
+			require.Error(t, err, "expected marshal to fail")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error")
+		})
+	}
+}
+
+func TestRedactFields(t *testing.T) {
+	type db struct {
+		User     string
+		Password secret `yaml:"pass"`
+	}
+	type embedded struct {
+		Token secret
+	}
+	type cfg struct {
+		embedded `yaml:",inline"`
+
+		DB       db
+		Replicas []db
+		ByName   map[string]*db `yaml:"by_name"`
+		Key      *secretPtr
+		Ignored  secret `yaml:"-"`
+		Next     *cfg
+		private  secret
+	}
+	assert.Equal(t, []string{
+		"token",
+		"db.pass",
+		"replicas.*.pass",
+		"by_name.*.pass",
+		"key",
+		// Self-referential types aren't followed.
+	}, redactedPaths(reflect.TypeOf(&cfg{})), "unexpected redacted paths")
+
+	p := mustYAML(t, `
+token: abc
+db: {user: admin, pass: hunter2}
+replicas: [{user: r, pass: r2}]
+key: {value: k}
+`)
+	out, err := p.Get(Root).Marshal(FormatYAML, RedactFields(cfg{}))
+	require.NoError(t, err, "marshal failed")
+	assert.Equal(t, `db:
+  pass: <redacted>
+  user: admin
+key: <redacted>
+replicas:
+- pass: <redacted>
+  user: r
+token: <redacted>
+`, string(out), "unexpected YAML")
+}
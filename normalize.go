@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeKeys makes mapping keys that differ only in case or in the use of
+// underscores and hyphens equivalent, so that maxConns, max_conns, MaxConns,
+// and max-conns all refer to the same key. It affects merging, Get, and
+// Populate, which matches keys to struct fields' YAML names after
+// normalization.
+//
+// Merged mappings keep the spelling of each key used by the highest-priority
+// source. In strict mode, it's an error for a source to spell a key
+// differently than a lower-priority source does, or to spell it two ways
+// itself: normalization is intended to accept keys from authors with
+// different conventions, not to make conflicting keys legal. In permissive
+// mode, the values of such keys are merged.
+func NormalizeKeys() YAMLOption {
+	return optionFunc(func(c *config) {
+		c.normalize = normalizeKey
+	})
+}
+
+// normalizeKey folds case and removes underscores and hyphens.
+func normalizeKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, k)
+}
+
+// sameKey reports whether two keys are the same, normalizing them if
+// normalize isn't nil.
+func sameKey(a, b string, normalize func(string) string) bool {
+	return a == b || normalize != nil && normalize(a) == normalize(b)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeKey(t *testing.T) {
+	for _, k := range []string{"maxConns", "max_conns", "MaxConns", "max-conns", "MAX_CONNS"} {
+		assert.Equal(t, "maxconns", normalizeKey(k), "unexpected normalization of %q", k)
+	}
+}
+
+func TestNormalizeKeysGet(t *testing.T) {
+	p, err := NewYAML(NormalizeKeys(), Source(strings.NewReader(`
+server:
+  maxConns: 10
+  read-timeout: 1s
+  Hosts: [{HostName: a}]
+`)))
+	require.NoError(t, err, "couldn't construct provider")
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{"server.max_conns", 10},
+		{"Server.MaxConns", 10},
+		{"server.readTimeout", "1s"},
+		{"server.hosts.0.host_name", "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			v := p.Get(tt.key)
+			require.True(t, v.HasValue(), "expected a value")
+			assert.Equal(t, tt.want, v.Value(), "unexpected value")
+		})
+	}
+
+	assert.Equal(t, []string{"Hosts", "maxConns", "read-timeout"}, p.Get("server").Keys(), "keys should keep their spelling")
+	assert.Len(t, p.Get(Root).Query("SERVER.*"), 3, "queries should normalize literal segments")
+	assert.Equal(t, []Origin{{Path: []string{"maxConns"}, Source: "source 1"}}, p.Get("SERVER").Origins()[1:2], "unexpected origins")
+
+	plain := mustYAML(t, "maxConns: 10")
+	assert.False(t, plain.Get("max_conns").HasValue(), "keys shouldn't be normalized by default")
+}
+
+func TestNormalizeKeysPopulate(t *testing.T) {
+	type host struct {
+		HostName string `yaml:"host_name"`
+	}
+	type server struct {
+		MaxConns    int    `yaml:"max_conns"`
+		ReadTimeout string `yaml:"read_timeout"`
+		Hosts       []host
+		Limits      map[string]int
+		Legacy      int `yaml:"new_name" config:"alias=oldName"`
+	}
+
+	p, err := NewYAML(NormalizeKeys(), Source(strings.NewReader(`
+maxConns: 10
+Read-Timeout: 1s
+hosts: [{hostName: a}, {HOST_NAME: b}]
+limits: {perHost: 1}
+old_name: 3
+`)))
+	require.NoError(t, err, "couldn't construct provider")
+
+	var s server
+	require.NoError(t, p.Get(Root).Populate(&s), "populate failed")
+	assert.Equal(t, server{
+		MaxConns:    10,
+		ReadTimeout: "1s",
+		Hosts:       []host{{"a"}, {"b"}},
+		Limits:      map[string]int{"perHost": 1},
+		Legacy:      3,
+	}, s, "unexpected result")
+
+	plain, err := NewYAML(Source(strings.NewReader("maxConns: 10")))
+	require.NoError(t, err, "couldn't construct provider")
+	assert.Error(t, plain.Get(Root).Populate(&server{}), "keys shouldn't be normalized by default")
+}
+
+func TestNormalizeKeysErrors(t *testing.T) {
+	p, err := NewYAML(NormalizeKeys(), Source(strings.NewReader("a: 1\n\nmaxConns: abc")))
+	require.NoError(t, err, "couldn't construct provider")
+	var s struct {
+		A        int
+		MaxConns int `yaml:"max_conns"`
+	}
+	err = p.Get(Root).PopulateAll(&s)
+	require.Error(t, err, "expected populate to fail")
+	assert.Contains(t, err.Error(), "max_conns: ", "expected the field's path")
+	assert.Contains(t, err.Error(), ":3)", "expected the respelled key's line")
+}
+
+func TestNormalizeKeysConflicts(t *testing.T) {
+	base := "server: {max_conns: 1, hosts: [a]}"
+	override := "server: {maxConns: 2}"
+
+	t.Run("strict", func(t *testing.T) {
+		_, err := NewYAML(NormalizeKeys(), Source(strings.NewReader(base)), Source(strings.NewReader(override)))
+		require.Error(t, err, "expected a conflict")
+		assert.Contains(t, err.Error(), `key "maxConns" conflicts with key "max_conns"`, "unexpected error")
+	})
+
+	t.Run("strict within source", func(t *testing.T) {
+		_, err := NewYAML(NormalizeKeys(), Source(strings.NewReader("{max_conns: 1, MaxConns: 1}")))
+		require.Error(t, err, "expected a conflict")
+		assert.Contains(t, err.Error(), "are the same after normalization", "unexpected error")
+	})
+
+	t.Run("permissive", func(t *testing.T) {
+		p, err := NewYAML(
+			NormalizeKeys(),
+			Permissive(),
+			Source(strings.NewReader(base)),
+			Source(strings.NewReader(override)),
+		)
+		require.NoError(t, err, "couldn't construct provider")
+		assert.Equal(t, map[interface{}]interface{}{
+			"maxConns": 2,
+			"hosts":    []interface{}{"a"},
+		}, p.Get("server").Value(), "later spellings should win")
+		assert.Equal(t, []Origin{
+			{Path: []string{"hosts"}, Source: "source 1"},
+			{Path: []string{"maxConns"}, Source: "source 2"},
+		}, p.Get("server").Origins(), "unexpected origins")
+	})
+
+	t.Run("without normalization", func(t *testing.T) {
+		p, err := NewYAML(Source(strings.NewReader(base)), Source(strings.NewReader(override)))
+		require.NoError(t, err, "couldn't construct provider")
+		assert.Equal(t, []string{"hosts", "maxConns", "max_conns"}, p.Get("server").Keys(), "keys should be distinct")
+	})
+
+	t.Run("WithDefault", func(t *testing.T) {
+		p, err := NewYAML(NormalizeKeys(), Source(strings.NewReader(override)))
+		require.NoError(t, err, "couldn't construct provider")
+		v, err := p.Get("server.max_conns").WithDefault(5)
+		require.NoError(t, err, "WithDefault failed")
+		assert.Equal(t, 2, v.Value(), "unexpected value")
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	"go.uber.org/config/internal/schema"
 	"go.uber.org/multierr"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -104,7 +105,7 @@ func Source(r io.Reader) YAMLOption {
 		return failed(err)
 	}
 	return optionFunc(func(c *config) {
-		c.sources = append(c.sources, source{bytes: all})
+		c.sources = append(c.sources, source{bytes: all, name: readerName(r)})
 	})
 }
 
@@ -120,7 +121,7 @@ func RawSource(r io.Reader) YAMLOption {
 		return failed(err)
 	}
 	return optionFunc(func(c *config) {
-		c.sources = append(c.sources, source{bytes: all, raw: true})
+		c.sources = append(c.sources, source{bytes: all, raw: true, name: readerName(r)})
 	})
 }
 
@@ -141,7 +142,7 @@ func File(name string) YAMLOption {
 		return failed(err)
 	}
 	return optionFunc(func(c *config) {
-		c.sources = append(c.sources, source{bytes: all})
+		c.sources = append(c.sources, source{bytes: all, name: name})
 	})
 }
 
@@ -158,16 +159,55 @@ func Static(val interface{}) YAMLOption {
 	})
 }
 
+// Schema validates the merged configuration against a JSON Schema, so that
+// NewYAML fails if the configuration doesn't conform. Variables are expanded
+// before validation. Every violation is reported as a *ValidationError
+// carrying the configuration path and the name of the source that supplied
+// the offending value; use multierr.Errors to inspect them individually.
+//
+// Schemas must be JSON. Only a subset of JSON Schema draft 2020-12 is
+// supported:
+//
+//	$ref ($defs and definitions, local references only), $defs, definitions
+//	type, enum, const
+//	allOf, anyOf, oneOf, not
+//	properties, patternProperties, additionalProperties, required,
+//	  minProperties, maxProperties
+//	items, prefixItems, minItems, maxItems, uniqueItems
+//	minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf
+//	minLength, maxLength, pattern
+//
+// Other keywords are ignored, and patterns use Go's regular expression
+// syntax. Since JSON only allows string keys, YAML mapping keys are compared
+// with property names using their string forms.
+func Schema(r io.Reader) YAMLOption {
+	s, err := schema.Parse(r)
+	if err != nil {
+		return failed(err)
+	}
+	return optionFunc(func(c *config) {
+		c.schemas = append(c.schemas, s)
+	})
+}
+
 // appendSources appends the given list of YAML sources as-is. Variable
 // expansion will be performed on all passed sources.
-func appendSources(srcs [][]byte) YAMLOption {
+func appendSources(srcs [][]byte, names []string) YAMLOption {
 	return optionFunc(func(c *config) {
-		for _, src := range srcs {
-			c.sources = append(c.sources, source{bytes: src})
+		for i, src := range srcs {
+			c.sources = append(c.sources, source{bytes: src, name: names[i]})
 		}
 	})
 }
 
+// readerName returns the name of a reader that has one, like an *os.File.
+func readerName(r io.Reader) string {
+	if n, ok := r.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return ""
+}
+
 func failed(err error) YAMLOption {
 	return optionFunc(func(c *config) {
 		c.err = multierr.Append(c.err, err)
@@ -177,12 +217,20 @@ func failed(err error) YAMLOption {
 type source struct {
 	bytes []byte
 	raw   bool
+	name  string // used to explain the origin of values, see Value.Origins
 }
 
 type config struct {
-	name    string
-	strict  bool
-	sources []source
-	lookup  LookupFunc
-	err     error
+	name         string
+	strict       bool
+	sources      []source
+	lookup       LookupFunc
+	schemas      []*schema.Schema
+	hooks        []decodeHook
+	deprecations []func(Deprecation)
+	normalize    func(string) string
+	v3           bool
+	lint         bool
+	lints        []func(Ambiguity)
+	err          error
 }
@@ -0,0 +1,221 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"go.uber.org/config/internal/merge"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// An Origin identifies the source that supplied a value.
+type Origin struct {
+	// Path is the location of the value, relative to the Value whose origins
+	// were requested.
+	Path []string
+	// Source names the configuration source. Files are named by their paths,
+	// as are readers with a Name method (like *os.File). Other sources are
+	// named by their position in the list of sources, starting at one.
+	Source string
+}
+
+// Origins explains where each part of the value came from. It returns the
+// origin of each scalar, sequence, null, and empty mapping in the value,
+// ordered by path. Since sequences are replaced rather than merged, they're
+// always supplied by a single source.
+func (v Value) Origins() []Origin {
+	if !v.HasValue() {
+		return nil
+	}
+	root := v.provider.origins()
+	node, ok := root.at(v.path, v.provider.normalize)
+	if !ok {
+		return nil
+	}
+	var origins []Origin
+	node.collect([]string{}, v.provider.names, &origins)
+	return origins
+}
+
+// origins replays the merge of the provider's sources, recording which source
+// last set each value. The result is computed once and cached.
+func (y *YAML) origins() *originNode {
+	y.originsOnce.Do(func() {
+		y.originTree = y.replayOrigins()
+	})
+	return y.originTree
+}
+
+// replayOrigins builds the tree of origins. Sources were already
+// successfully merged during construction, so decoding can't fail here.
+func (y *YAML) replayOrigins() *originNode {
+	root := &originNode{}
+	if y.v3 {
+		// Reuse the documents parsed during construction, so that keys like on
+		// and yes stay strings.
+		for source, nodes := range y.nodes {
+			for _, n := range nodes {
+				contents, err := nodeValue(n, y.strict)
+				if err != nil {
+					return &originNode{}
+				}
+				root.set(contents, source, y.normalize)
+			}
+		}
+		return root
+	}
+
+	docs, err := merge.Prepare(y.raw, false /* v3 */)
+	if err != nil {
+		return &originNode{}
+	}
+	for _, doc := range docs {
+		dec := yaml.NewDecoder(bytes.NewReader(doc.Bytes))
+		var contents interface{}
+		if err := dec.Decode(&contents); err == io.EOF {
+			continue
+		} else if err != nil {
+			return &originNode{}
+		}
+		root.set(doc.Unwrap(contents), doc.Source, y.normalize)
+	}
+	return root
+}
+
+// An originNode mirrors the structure of the merged configuration. Leaves
+// record the index of the source that set them.
+type originNode struct {
+	source   int
+	present  bool
+	sequence bool
+	children map[interface{}]*originNode // nil unless the node is a mapping
+}
+
+func (n *originNode) set(value interface{}, source int, normalize func(string) string) {
+	n.source, n.present = source, true
+	_, n.sequence = value.([]interface{})
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		// Scalars, sequences, and nulls replace lower-priority values.
+		n.children = nil
+		return
+	}
+	if n.children == nil {
+		n.children = make(map[interface{}]*originNode, len(m))
+	}
+	// Visit keys in sorted order so that normalized keys are folded in the
+	// same order as they were when merging.
+	for _, k := range sortedKeys(m) {
+		child, ok := n.children[k]
+		if !ok {
+			child = n.renormalize(k, normalize)
+		}
+		child.set(m[k], source, normalize)
+	}
+}
+
+// renormalize returns a child for a new key. If the key is the same as an
+// existing child's key after normalization, the existing child is moved to
+// the new key, since the merged configuration uses the latest spelling.
+func (n *originNode) renormalize(k interface{}, normalize func(string) string) *originNode {
+	if s, ok := k.(string); ok && normalize != nil {
+		want := normalize(s)
+		for existing, child := range n.children {
+			if es, ok := existing.(string); ok && normalize(es) == want {
+				delete(n.children, existing)
+				n.children[k] = child
+				return child
+			}
+		}
+	}
+	child := &originNode{}
+	n.children[k] = child
+	return child
+}
+
+// validationError builds a *ValidationError for the value at path.
+func (y *YAML) validationError(path []string, err error) *ValidationError {
+	verr := &ValidationError{
+		Path: joinPath(path),
+		Err:  err,
+	}
+	if n := y.origins().nearest(path, y.normalize); n.present {
+		verr.Source = y.names[n.source]
+		line, column := y.position(n.source, path)
+		verr.Line = line
+		if y.v3 {
+			// Columns are only reliable when yaml.v3 parsed the configuration.
+			verr.Column = column
+		}
+	}
+	return verr
+}
+
+// nearest returns the deepest node along the path.
+func (n *originNode) nearest(path []string, normalize func(string) string) *originNode {
+	cur := n
+	for _, segment := range path {
+		next, ok := cur.at([]string{segment}, normalize)
+		if !ok {
+			break
+		}
+		cur = next
+	}
+	return cur
+}
+
+func (n *originNode) at(path []string, normalize func(string) string) (*originNode, bool) {
+	cur := n
+	for _, segment := range path {
+		if cur.sequence {
+			// A single source supplies the whole sequence.
+			return cur, cur.present
+		}
+		if cur.children == nil {
+			return nil, false
+		}
+		children := cur.children
+		key, ok := resolveKey(children, segment, normalize)
+		if !ok {
+			return nil, false
+		}
+		cur = children[key]
+	}
+	return cur, cur.present
+}
+
+func (n *originNode) collect(path []string, names []string, origins *[]Origin) {
+	if len(n.children) == 0 {
+		*origins = append(*origins, Origin{Path: path, Source: names[n.source]})
+		return
+	}
+	keys := make([]interface{}, 0, len(n.children))
+	for k := range n.children {
+		keys = append(keys, k)
+	}
+	sortKeys(keys)
+	for _, k := range keys {
+		n.children[k].collect(extend(path, fmt.Sprint(k)), names, origins)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrigins(t *testing.T) {
+	f, err := ioutil.TempFile("" /* dir */, "test-origins" /* prefix */)
+	require.NoError(t, err, "couldn't create temporary file")
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("server: {port: 443}\nhosts: [c]\n")
+	require.NoError(t, err, "couldn't write temporary file")
+	require.NoError(t, f.Close(), "couldn't close temporary file")
+
+	p, err := NewYAML(
+		Source(strings.NewReader("server: {port: 80, host: localhost}\nhosts: [a, b]\nempty: {}\n")),
+		File(f.Name()),
+		Source(strings.NewReader("")), // empty sources don't set anything
+		Static(map[string]interface{}{"debug": nil}),
+	)
+	require.NoError(t, err, "couldn't construct provider")
+
+	assert.Equal(t, []Origin{
+		{Path: []string{"debug"}, Source: "source 4"},
+		{Path: []string{"empty"}, Source: "source 1"},
+		{Path: []string{"hosts"}, Source: f.Name()},
+		{Path: []string{"server", "host"}, Source: "source 1"},
+		{Path: []string{"server", "port"}, Source: f.Name()},
+	}, p.Get(Root).Origins(), "unexpected origins")
+
+	assert.Equal(t, []Origin{
+		{Path: []string{}, Source: f.Name()},
+	}, p.Get("server.port").Origins(), "unexpected origins for scalar")
+	assert.Nil(t, p.Get("not_there").Origins(), "expected no origins for missing key")
+	assert.Equal(t, []Origin{
+		{Path: []string{}, Source: f.Name()},
+	}, p.Get("hosts.0").Origins(), "expected sequence elements to share the sequence's origin")
+	assert.Nil(t, p.Get("hosts.10").Origins(), "expected no origins past the end of a sequence")
+
+	named, err := os.Open(f.Name())
+	require.NoError(t, err, "couldn't open temporary file")
+	defer named.Close()
+	p, err = NewYAML(Source(named))
+	require.NoError(t, err, "couldn't construct provider")
+	assert.Equal(t, f.Name(), p.Get("server.port").Origins()[0].Source, "expected readers to be named")
+}
+
+func TestOriginsReplacement(t *testing.T) {
+	p, err := NewYAML(
+		Permissive(),
+		Source(strings.NewReader("a: {b: 1, c: 2}\nd: scalar\n2: int\n'2': string")),
+		Source(strings.NewReader("a: ~\nd: {e: 3}\n2: new int")),
+	)
+	require.NoError(t, err, "couldn't construct provider")
+	assert.Equal(t, []Origin{
+		{Path: []string{"2"}, Source: "source 2"},
+		{Path: []string{"2"}, Source: "source 1"},
+		{Path: []string{"a"}, Source: "source 2"},
+		{Path: []string{"d", "e"}, Source: "source 2"},
+	}, p.Get(Root).Origins(), "unexpected origins")
+}
+
+func TestOriginsWithDefault(t *testing.T) {
+	p, err := NewYAML(Source(strings.NewReader("foo: bar")))
+	require.NoError(t, err, "couldn't construct provider")
+	v, err := p.Get(Root).WithDefault(map[string]string{"baz": "quux"})
+	require.NoError(t, err, "couldn't set default")
+	assert.Equal(t, []Origin{
+		{Path: []string{"baz"}, Source: "default"},
+		{Path: []string{"foo"}, Source: "source 1"},
+	}, v.Origins(), "unexpected origins")
+}
+
+func TestOriginsYAMLv3(t *testing.T) {
+	p, err := NewYAML(
+		YAMLv3(),
+		Source(strings.NewReader("on: yes\nport: 80")),
+		Source(strings.NewReader("port: 8080")),
+	)
+	require.NoError(t, err, "couldn't construct provider")
+	assert.Equal(t, []Origin{
+		{Path: []string{"on"}, Source: "source 1"},
+		{Path: []string{"port"}, Source: "source 2"},
+	}, p.Get(Root).Origins(), "expected YAML 1.2 keys")
+}
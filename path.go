@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// splitKey splits a key into path segments. Segments are separated by
+// periods, and a key segment that contains periods can be written in two
+// ways: by escaping special characters with backslashes, or as a quoted
+// string in brackets. Brackets may also hold sequence indexes. These keys are
+// equivalent:
+//
+//	hosts["example.com"].ports[0]
+//	hosts.example\.com.ports.0
+//
+// To preserve backward compatibility, keys that aren't well-formed under
+// these rules are split on periods alone.
+func splitKey(key string) []string {
+	if !strings.ContainsAny(key, `\[`) {
+		return strings.Split(key, _separator)
+	}
+	if segments, ok := parseKey(key); ok {
+		return segments
+	}
+	return strings.Split(key, _separator)
+}
+
+func parseKey(key string) ([]string, bool) {
+	var (
+		segments []string
+		cur      strings.Builder
+		// Whether the last segment was bracketed and hasn't been followed by a
+		// separator yet.
+		closed bool
+	)
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if closed && c != '.' && c != '[' {
+			return nil, false
+		}
+		switch c {
+		case '\\':
+			if i+1 == len(key) {
+				return nil, false
+			}
+			i++
+			cur.WriteByte(key[i])
+		case '.':
+			if !closed {
+				segments = append(segments, cur.String())
+				cur.Reset()
+			}
+			closed = false
+		case '[':
+			if cur.Len() > 0 {
+				segments = append(segments, cur.String())
+				cur.Reset()
+			}
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return nil, false
+			}
+			segment, ok := parseBracket(key[i+1:], &end)
+			if !ok {
+				return nil, false
+			}
+			segments = append(segments, segment)
+			i += end
+			closed = true
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if !closed {
+		segments = append(segments, cur.String())
+	}
+	return segments, true
+}
+
+// parseBracket parses the contents of a bracketed segment, which starts
+// just after the opening bracket. On input, end is the offset of the first
+// closing bracket relative to the opening one; since quoted strings may
+// contain brackets, it's updated to the offset of the bracket that actually
+// closes the segment.
+func parseBracket(s string, end *int) (string, bool) {
+	if strings.HasPrefix(s, `"`) {
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil || !strings.HasPrefix(s[len(quoted):], "]") {
+			return "", false
+		}
+		unquoted, err := strconv.Unquote(quoted)
+		if err != nil {
+			return "", false
+		}
+		*end = len(quoted) + 1
+		return unquoted, true
+	}
+	index := s[:*end-1]
+	if _, err := strconv.Atoi(index); err != nil {
+		return "", false
+	}
+	return index, true
+}
+
+// resolvePath splits a key and appends it to base. Before escaped and
+// bracketed segments were supported, backslashes and brackets had no special
+// meaning, so if the parsed key doesn't refer to any configuration but the
+// key split only on periods does, the latter wins.
+func (y *YAML) resolvePath(base []string, key string) []string {
+	resolved := make([]string, len(base))
+	copy(resolved, base)
+	segments := splitKey(key)
+	if strings.ContainsAny(key, `\[`) {
+		if _, ok := y.at(append(resolved, segments...)); !ok {
+			plain := append(resolved, strings.Split(key, _separator)...)
+			if _, ok := y.at(plain); ok {
+				return plain
+			}
+		}
+	}
+	return append(resolved, segments...)
+}
+
+// JoinPath formats path segments as a key that Get resolves to the same value
+// as GetPath(segments...). Segments containing periods, brackets, or
+// backslashes are quoted, so paths like those in Origin and Match round-trip.
+func JoinPath(segments ...string) string {
+	return joinPath(segments)
+}
+
+// joinPath formats path segments as a key, quoting any segments that
+// wouldn't survive splitKey. The result can be passed back to Get.
+func joinPath(path []string) string {
+	var sb strings.Builder
+	for i, segment := range path {
+		if segment == "" || strings.ContainsAny(segment, `.[\`) {
+			sb.WriteString("[")
+			sb.WriteString(strconv.Quote(segment))
+			sb.WriteString("]")
+			continue
+		}
+		if i > 0 {
+			sb.WriteString(_separator)
+		}
+		sb.WriteString(segment)
+	}
+	return sb.String()
+}
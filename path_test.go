@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitKey(t *testing.T) {
+	tests := []struct {
+		give string
+		want []string
+	}{
+		{"", []string{""}},
+		{"a.b.c", []string{"a", "b", "c"}},
+		{`hosts.example\.com.port`, []string{"hosts", "example.com", "port"}},
+		{`hosts["example.com"].port`, []string{"hosts", "example.com", "port"}},
+		{`["k8s.io/name"]`, []string{"k8s.io/name"}},
+		{`a["b"]["c"]`, []string{"a", "b", "c"}},
+		{`servers[0].host`, []string{"servers", "0", "host"}},
+		{`servers[-1]`, []string{"servers", "-1"}},
+		{`a["quote\"d]"]`, []string{"a", `quote"d]`}},
+		{`a\\b`, []string{`a\b`}},
+		{`a\[0]`, []string{"a[0]"}},
+		{`a.["b.c"]`, []string{"a", "b.c"}},
+
+		// Malformed keys fall back to splitting on periods.
+		{`a[0`, []string{"a[0"}},
+		{`a[x].b`, []string{"a[x]", "b"}},
+		{`a["b"]c`, []string{`a["b"]c`}},
+		{`a["b].c`, []string{`a["b]`, "c"}},
+		{`trailing\`, []string{`trailing\`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitKey(tt.give), "unexpected segments")
+		})
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		give []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"a", "b"}, "a.b"},
+		{[]string{"hosts", "example.com", "port"}, `hosts["example.com"].port`},
+		{[]string{"k8s.io/name"}, `["k8s.io/name"]`},
+		{[]string{"a", `back\slash`}, `a["back\\slash"]`},
+		{[]string{"a", ""}, `a[""]`},
+		{[]string{"servers", "0"}, "servers.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, JoinPath(tt.give...), "unexpected key")
+			if len(tt.give) > 0 {
+				assert.Equal(t, tt.give, splitKey(joinPath(tt.give)), "key didn't round-trip")
+			}
+		})
+	}
+}
+
+func TestDottedKeys(t *testing.T) {
+	p := mustYAML(t, `
+hosts:
+  example.com: {port: 443}
+  k8s.io/name: {port: 80}
+  plain: {port: 8080}
+servers: [{host: a}]
+`)
+
+	tests := []struct {
+		desc string
+		v    Value
+		want interface{}
+	}{
+		{"escaped", p.Get(`hosts.example\.com.port`), 443},
+		{"bracketed", p.Get(`hosts["k8s.io/name"].port`), 80},
+		{"bracketed index", p.Get(`servers[0].host`), "a"},
+		{"GetPath", p.GetPath("hosts", "example.com", "port"), 443},
+		{"Value.GetPath", p.Get("hosts").GetPath("k8s.io/name", "port"), 80},
+		{"Value.Get", p.Get("hosts").Get(`["example.com"]`).Get("port"), 443},
+		{"scoped", NewScopedProvider(`hosts["example.com"]`, p).Get("port"), 443},
+		{"scoped escaped key", NewScopedProvider("hosts", p).Get(`example\.com.port`), 443},
+		{"scoped root", NewScopedProvider("hosts.plain", p).Get(Root), map[interface{}]interface{}{"port": 8080}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			require.True(t, tt.v.HasValue(), "expected a value")
+			assert.Equal(t, tt.want, tt.v.Value(), "unexpected value")
+		})
+	}
+
+	assert.False(t, p.Get("hosts.example.com.port").HasValue(), "unescaped periods should still separate segments")
+
+}
+
+func TestPlainKeysWithEscapes(t *testing.T) {
+	p := mustYAML(t, `
+dirs:
+  'C:\temp': {size: 1}
+  a\b: {size: 2}
+  ab: {size: 3}
+  list[0]: {size: 4}
+  "[x]": {size: 5}
+`)
+
+	tests := []struct {
+		key  string
+		want interface{}
+	}{
+		{`C:\temp.size`, 1},
+		{`list[0].size`, 4},
+		{`[x].size`, 5},
+		// Both readings refer to configuration, so the escape wins.
+		{`a\b.size`, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			v := p.Get("dirs." + tt.key)
+			require.True(t, v.HasValue(), "expected key to resolve")
+			assert.Equal(t, tt.want, v.Value(), "unexpected value")
+			assert.Equal(t, tt.want, p.Get("dirs").Get(tt.key).Value(), "unexpected value from Value.Get")
+		})
+	}
+}
+
+func TestWithDefaultPaths(t *testing.T) {
+	p := mustYAML(t, "hosts: {example.com: {port: 443}}\nbyint: {1: {a: x}}\nlist: [{a: 1}]")
+
+	t.Run("dotted key", func(t *testing.T) {
+		v, err := p.GetPath("hosts", "example.com").WithDefault(map[string]interface{}{"port": 80, "tls": true})
+		require.NoError(t, err, "WithDefault failed")
+		assert.Equal(t, map[interface{}]interface{}{"port": 443, "tls": true}, v.Value(), "unexpected value")
+	})
+
+	t.Run("integer key", func(t *testing.T) {
+		v, err := p.Get("byint.1").WithDefault(map[string]interface{}{"b": "y"})
+		require.NoError(t, err, "WithDefault failed")
+		assert.Equal(t, map[interface{}]interface{}{"a": "x", "b": "y"}, v.Value(), "unexpected value")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		v, err := p.GetPath("hosts", "new.host").WithDefault(1)
+		require.NoError(t, err, "WithDefault failed")
+		assert.Equal(t, 1, v.Value(), "unexpected value")
+		assert.Equal(t, 1, v.provider.GetPath("hosts", "new.host").Value(), "default nested under the wrong key")
+	})
+
+	t.Run("within sequence", func(t *testing.T) {
+		_, err := p.Get("list.0.b").WithDefault(2)
+		require.Error(t, err, "expected an error")
+		assert.Contains(t, err.Error(), "can't apply a default within the sequence at list", "unexpected error")
+	})
+}
+
+func TestDottedKeyErrors(t *testing.T) {
+	p := mustYAML(t, "hosts: {example.com: {port: abc}}")
+	var cfg struct {
+		Hosts map[string]struct{ Port int }
+	}
+	err := p.Get(Root).PopulateAll(&cfg)
+	require.Error(t, err, "expected populate to fail")
+	assert.Contains(t, err.Error(), `hosts["example.com"].port: cannot unmarshal`, "expected paths in errors to be quoted")
+}
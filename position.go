@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strconv"
+
+	"go.uber.org/config/internal/merge"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// position returns the one-based line and column in a source that defines
+// the value at path, or the nearest enclosing value that the source defines.
+// It returns zeros if the source can't be parsed or doesn't define any part
+// of the path.
+// If several documents in the source define the path, the deepest match
+// wins, with ties going to the last document, since it takes priority.
+//
+// gopkg.in/yaml.v2 doesn't expose positions, so sources are re-parsed with
+// gopkg.in/yaml.v3. The parsed sources are cached.
+func (y *YAML) position(source int, path []string) (line, column int) {
+	y.nodesOnce.Do(func() {
+		y.nodes = make([][]*yamlv3.Node, len(y.raw))
+		docs, _ := merge.Prepare(y.raw, y.v3)
+		for _, doc := range docs {
+			var n yamlv3.Node
+			if err := yamlv3.Unmarshal(doc.Bytes, &n); err != nil {
+				continue
+			}
+			if root := doc.UnwrapNode(&n); root != nil {
+				y.nodes[doc.Source] = append(y.nodes[doc.Source], root)
+			}
+		}
+	})
+	if source < 0 || source >= len(y.nodes) {
+		return 0, 0
+	}
+	depth := -1
+	for _, n := range y.nodes[source] {
+		if l, c, d := nodePosition(n, path, y.normalize); d >= depth {
+			line, column, depth = l, c, d
+		}
+	}
+	return line, column
+}
+
+// nodePosition returns the line and column of the deepest node along the
+// path, and the number of path segments it matched.
+func nodePosition(n *yamlv3.Node, path []string, normalize func(string) string) (int, int, int) {
+	line, column := n.Line, n.Column
+	for depth, segment := range path {
+		for n.Kind == yamlv3.AliasNode {
+			n = n.Alias
+		}
+		// Nodes aliased from other sources have no position, so positions
+		// are only updated for nodes with lines.
+		var next *yamlv3.Node
+		switch n.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if k := n.Content[i]; sameKey(k.Value, segment, normalize) {
+					// Point at the key, since that's where a reader looks.
+					if k.Line > 0 {
+						line, column = k.Line, k.Column
+					}
+					next = n.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(n.Content) {
+				next = n.Content[i]
+				if next.Line > 0 {
+					line, column = next.Line, next.Column
+				}
+			}
+		}
+		if next == nil {
+			return line, column, depth
+		}
+		n = next
+	}
+	return line, column, len(path)
+}
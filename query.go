@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	_wildcard          = "*"
+	_recursiveWildcard = "**"
+)
+
+// A Match is a value found by Query.
+type Match struct {
+	// Path is the concrete path to the value, relative to the value that was
+	// queried. Wildcards are replaced by the keys and indexes they matched,
+	// so the path can be passed to GetPath.
+	Path  []string
+	Value Value
+}
+
+// Query returns the values whose paths, relative to v, match a pattern. A "*"
+// segment in the pattern matches any single key or sequence index, and a
+// "**" segment matches any number of segments, including none. Other
+// segments are resolved as they are by Get, so they may be escaped,
+// bracketed, or negative sequence indexes. For example, "services.*.port"
+// matches the port of every service, and "**.password" matches every
+// password in the configuration.
+//
+// Matches are returned in the order Walk would visit them, and each value is
+// returned at most once. If nothing matches, Query returns nil.
+func (v Value) Query(pattern string) []Match {
+	val, ok := v.provider.at(v.path)
+	if !ok {
+		return nil
+	}
+	var matches []Match
+	query(val, pattern, v.provider.normalize, func(rel []string, _ interface{}) {
+		abs := make([]string, 0, len(v.path)+len(rel))
+		abs = append(append(abs, v.path...), rel...)
+		matches = append(matches, Match{
+			Path:  rel,
+			Value: Value{path: abs, provider: v.provider},
+		})
+	})
+	return matches
+}
+
+// query calls fn with the path and contents of each value within val that
+// matches a pattern, depth-first. Query, Redact, and DiffKey all match
+// patterns this way, so they always agree on what a pattern selects.
+//
+// Rather than backtracking, it tracks the set of positions in the pattern
+// that each value could be matched at, so a tree is traversed only once
+// regardless of how many wildcards the pattern contains.
+func query(val interface{}, pattern string, normalize func(string) string, fn func([]string, interface{})) {
+	// As with Get, the Root key refers to the whole value.
+	segments := []string{}
+	if pattern != Root {
+		segments = splitKey(pattern)
+	}
+	q := querier{pattern: segments, normalize: normalize, fn: fn}
+	q.visit([]string{}, val, q.closure([]int{0}))
+}
+
+type querier struct {
+	pattern   []string
+	normalize func(string) string // see NormalizeKeys
+	fn        func([]string, interface{})
+}
+
+// closure adds the positions reachable by letting recursive wildcards match
+// no segments, and removes duplicates.
+func (q *querier) closure(positions []int) []int {
+	seen := make(map[int]bool, len(positions))
+	var out []int
+	for _, pos := range positions {
+		for ; !seen[pos]; pos++ {
+			seen[pos] = true
+			out = append(out, pos)
+			if pos == len(q.pattern) || q.pattern[pos] != _recursiveWildcard {
+				break
+			}
+		}
+	}
+	return out
+}
+
+func (q *querier) visit(path []string, val interface{}, positions []int) {
+	literal := true
+	for _, pos := range positions {
+		if pos == len(q.pattern) {
+			q.fn(path, val)
+			continue
+		}
+		if seg := q.pattern[pos]; seg == _wildcard || seg == _recursiveWildcard {
+			literal = false
+		}
+	}
+
+	if literal && len(positions) == 1 && positions[0] < len(q.pattern) {
+		// The common case of a plain path needs only a lookup, not a scan of
+		// every child.
+		pos := positions[0]
+		if key, next, ok := lookup(val, q.pattern[pos], q.normalize); ok {
+			q.visit(extend(path, key), next, q.closure([]int{pos + 1}))
+		}
+		return
+	}
+
+	// Resolve literal segments once, rather than for every child.
+	keys := make(map[int]string, len(positions))
+	for _, pos := range positions {
+		if pos < len(q.pattern) {
+			if key, _, ok := lookup(val, q.pattern[pos], q.normalize); ok {
+				keys[pos] = key
+			}
+		}
+	}
+
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		for _, k := range sortedKeys(val) {
+			q.descend(extend(path, fmt.Sprint(k)), val[k], positions, keys)
+		}
+	case []interface{}:
+		for i, elem := range val {
+			q.descend(extend(path, strconv.Itoa(i)), elem, positions, keys)
+		}
+	}
+}
+
+// descend visits a child if any of the pattern positions match its key, the
+// last segment of path. Literal segments match if they resolved to that key.
+func (q *querier) descend(path []string, next interface{}, positions []int, keys map[int]string) {
+	key := path[len(path)-1]
+	var advanced []int
+	for _, pos := range positions {
+		if pos == len(q.pattern) {
+			continue
+		}
+		switch seg := q.pattern[pos]; seg {
+		case _recursiveWildcard:
+			advanced = append(advanced, pos)
+		case _wildcard:
+			advanced = append(advanced, pos+1)
+		default:
+			if resolved, ok := keys[pos]; ok && resolved == key {
+				advanced = append(advanced, pos+1)
+			}
+		}
+	}
+	if len(advanced) > 0 {
+		q.visit(path, next, q.closure(advanced))
+	}
+}
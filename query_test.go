@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestQuery(t *testing.T) {
+	p := mustYAML(t, `
+password: root
+services:
+  api: {port: 80, db: {password: hunter2}}
+  web: {port: 8080, hosts: [a, b]}
+  example.com: {port: 443}
+servers:
+  - {host: h1, password: one}
+  - {host: h2}
+ports: {1: one, 2: two}
+`)
+
+	type match struct {
+		path  string
+		value interface{}
+	}
+
+	tests := []struct {
+		desc    string
+		from    string
+		pattern string
+		want    []match
+	}{
+		{
+			desc:    "single wildcard",
+			pattern: "services.*.port",
+			want: []match{
+				{"services.api.port", 80},
+				{`services.example\.com.port`, 443},
+				{"services.web.port", 8080},
+			},
+		},
+		{
+			desc:    "recursive wildcard",
+			pattern: "**.password",
+			want: []match{
+				{"password", "root"},
+				{"servers.0.password", "one"},
+				{"services.api.db.password", "hunter2"},
+			},
+		},
+		{
+			desc:    "sequence elements",
+			pattern: "servers.*.host",
+			want: []match{
+				{"servers.0.host", "h1"},
+				{"servers.1.host", "h2"},
+			},
+		},
+		{
+			desc:    "negative index",
+			pattern: "servers[-1].host",
+			want:    []match{{"servers.1.host", "h2"}},
+		},
+		{
+			desc:    "integer keys",
+			pattern: "ports.2",
+			want:    []match{{"ports.2", "two"}},
+		},
+		{
+			desc:    "bracketed key",
+			pattern: `*["example.com"].port`,
+			want:    []match{{`services.example\.com.port`, 443}},
+		},
+		{
+			desc:    "relative to value",
+			from:    "services.web",
+			pattern: "**",
+			want: []match{
+				{"", map[interface{}]interface{}{"port": 8080, "hosts": []interface{}{"a", "b"}}},
+				{"hosts", []interface{}{"a", "b"}},
+				{"hosts.0", "a"},
+				{"hosts.1", "b"},
+				{"port", 8080},
+			},
+		},
+		{
+			desc:    "repeated recursive wildcards",
+			from:    "services.api",
+			pattern: "**.**.password",
+			want:    []match{{"db.password", "hunter2"}},
+		},
+		{
+			desc:    "root",
+			from:    "servers.1",
+			pattern: Root,
+			want:    []match{{"", map[interface{}]interface{}{"host": "h2"}}},
+		},
+		{
+			desc:    "no matches",
+			pattern: "services.*.missing",
+		},
+		{
+			desc:    "missing value",
+			from:    "nope",
+			pattern: "**",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			from := tt.from
+			if from == "" {
+				from = Root
+			}
+			v := p.Get(from)
+			matches := v.Query(tt.pattern)
+
+			var got []match
+			for _, m := range matches {
+				require.True(t, m.Value.HasValue(), "matched value at %v should exist", m.Path)
+				assert.Equal(t, m.Value.Value(), v.GetPath(m.Path...).Value(), "match path should resolve to the matched value")
+				got = append(got, match{escapePath(m.Path), m.Value.Value()})
+			}
+			assert.Equal(t, tt.want, got, "unexpected matches")
+		})
+	}
+}
+
+func TestQueryMatchesWalkOrder(t *testing.T) {
+	p := mustYAML(t, "b: [{c: 1}, {c: 2}]\na: {c: 3, d: {c: 4}}\nc: 5")
+
+	var walked []string
+	require.NoError(t, p.Get(Root).Walk(func(path []string, v Value) error {
+		walked = append(walked, escapePath(path))
+		return nil
+	}), "Walk failed")
+
+	var queried []string
+	for _, m := range p.Get(Root).Query("**") {
+		queried = append(queried, escapePath(m.Path))
+	}
+	assert.Equal(t, walked, queried, "matching everything should visit values in walk order")
+}
+
+// escapePath formats a path with backslash escapes, to keep test tables
+// readable.
+func escapePath(path []string) string {
+	escaped := make([]string, len(path))
+	for i, seg := range path {
+		escaped[i] = strings.ReplaceAll(seg, ".", `\.`)
+	}
+	return strings.Join(escaped, ".")
+}
+
+func TestQueryRedactAndDiffKeyAgree(t *testing.T) {
+	const src = `
+groups:
+  a: {members: [{name: x}, {name: y}]}
+  c.d: {members: [{name: x}, {name: y}]}
+teams:
+  - {members: [{name: x}, {name: y}]}
+  - {members: [{name: x}, {name: y}]}
+`
+	old := mustYAML(t, src)
+	// Reversing every sequence of members changes every index.
+	new := mustYAML(t, strings.ReplaceAll(src, "[{name: x}, {name: y}]", "[{name: y}, {name: x}]"))
+	all := []string{`groups.a.members`, `groups["c.d"].members`, `teams.0.members`, `teams.1.members`}
+
+	patterns := []string{
+		"**.members",
+		"groups.*.members",
+		`groups["c.d"].members`,
+		`groups.c\.d.members`,
+		"teams.-1.members",
+		"*.*.members",
+		"teams.*",
+		"nothing.*.members",
+	}
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			queried := make(map[string]bool)
+			for _, m := range old.Get(Root).Query(pattern) {
+				queried[joinPath(m.Path)] = true
+			}
+
+			out, err := old.Get(Root).Marshal(FormatYAML, Redact(pattern))
+			require.NoError(t, err, "marshal failed")
+			var contents interface{}
+			require.NoError(t, yaml.Unmarshal(out, &contents), "couldn't unmarshal redacted output")
+			redacted := make(map[string]bool)
+			query(contents, "**", nil, func(path []string, val interface{}) {
+				if val == _redacted {
+					redacted[joinPath(path)] = true
+				}
+			})
+			assert.Equal(t, queried, redacted, "Redact and Query disagree")
+
+			// Sequences compared by key report no changes, since only their
+			// order differs.
+			keyed := make(map[string]bool)
+			for _, path := range all {
+				keyed[path] = true
+			}
+			for _, c := range Diff(old, new, Root, DiffKey(pattern, "name")) {
+				for i, segment := range c.Path {
+					if segment == "members" {
+						delete(keyed, joinPath(c.Path[:i+1]))
+					}
+				}
+			}
+			for _, path := range all {
+				assert.Equal(t, queried[path], keyed[path], "DiffKey and Query disagree about %s", path)
+			}
+		})
+	}
+}
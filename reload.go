@@ -0,0 +1,231 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// A Reloadable is a Provider whose contents can be replaced at runtime. It
+// wraps a function that builds a provider (usually by calling NewYAML with
+// the same options used at startup), and re-runs that function on each call
+// to Reload.
+//
+// Values retrieved from a Reloadable are snapshots: they continue to reflect
+// the configuration that was current when Get was called. Components that
+// need to react to changes should use Subscribe instead of polling.
+type Reloadable struct {
+	load func() (Provider, error)
+
+	reloadMu sync.Mutex // serializes reloads, and therefore notifications
+
+	mu         sync.RWMutex
+	current    Provider
+	generation uint64
+	lastErr    error
+
+	subsMu sync.Mutex
+	subs   []*subscription
+}
+
+var _ Provider = (*Reloadable)(nil)
+
+// NewReloadable constructs a Reloadable, calling load once to build the
+// initial configuration. If the initial load fails, NewReloadable returns the
+// error.
+func NewReloadable(load func() (Provider, error)) (*Reloadable, error) {
+	p, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Reloadable{
+		load:    load,
+		current: p,
+	}, nil
+}
+
+// Name returns the name of the current provider.
+func (r *Reloadable) Name() string {
+	return r.provider().Name()
+}
+
+// Get retrieves a value from the current configuration. See YAML.Get for a
+// description of the key format.
+func (r *Reloadable) Get(key string) Value {
+	return r.provider().Get(key)
+}
+
+// Generation reports how many times the configuration has been successfully
+// reloaded. The configuration built by NewReloadable is generation zero.
+func (r *Reloadable) Generation() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.generation
+}
+
+// LastError returns the error returned by the most recent call to Reload, if
+// any. Subscribers' panics aren't reload errors; see OnPanic.
+func (r *Reloadable) LastError() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastErr
+}
+
+func (r *Reloadable) provider() Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Reload rebuilds the configuration. If building the new provider fails, the
+// existing configuration remains in place and the error is returned.
+//
+// Otherwise, the new configuration replaces the old and all subscribers whose
+// keys changed are notified before Reload returns. Subscribers are notified
+// one at a time, in the order they subscribed, and concurrent calls to Reload
+// are serialized so that notifications are never reordered. A panicking
+// subscriber doesn't prevent delivery to the others or fail the reload; see
+// OnPanic.
+func (r *Reloadable) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	p, err := r.load()
+	if err != nil {
+		r.setLastError(err)
+		return err
+	}
+
+	r.mu.Lock()
+	old := r.current
+	r.current = p
+	r.generation++
+	r.mu.Unlock()
+
+	r.subsMu.Lock()
+	subs := make([]*subscription, len(r.subs))
+	copy(subs, r.subs)
+	r.subsMu.Unlock()
+
+	r.setLastError(nil)
+	for _, s := range subs {
+		s.notify(old, p)
+	}
+	return nil
+}
+
+func (r *Reloadable) setLastError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastErr = err
+}
+
+// Subscribe registers a function to be called after each reload that changes
+// the configuration at the supplied key. Changes are detected by comparing
+// the merged YAML at the key before and after the reload, so reformatting a
+// source or editing an unrelated key doesn't trigger a notification. Adding a
+// previously-absent key or removing a present one is a change, even if the
+// value is null.
+//
+// Subscribers run while Reload holds the lock that serializes reloads, so
+// they mustn't call Reload themselves: doing so deadlocks. Subscribers that
+// need to trigger another reload should do so from a separate goroutine.
+//
+// The returned function cancels the subscription. It's safe to call more
+// than once, including from within the subscriber itself.
+func (r *Reloadable) Subscribe(key string, fn func(old, new Value), opts ...SubscribeOption) (unsubscribe func()) {
+	s := &subscription{key: key, fn: fn}
+	for _, o := range opts {
+		o.applySubscription(s)
+	}
+
+	r.subsMu.Lock()
+	r.subs = append(r.subs, s)
+	r.subsMu.Unlock()
+
+	return func() {
+		if !s.cancelled.CompareAndSwap(false, true) {
+			return
+		}
+		r.subsMu.Lock()
+		defer r.subsMu.Unlock()
+		for i := range r.subs {
+			if r.subs[i] == s {
+				r.subs = append(r.subs[:i], r.subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// A SubscribeOption customizes a subscription.
+type SubscribeOption interface {
+	applySubscription(*subscription)
+}
+
+type subscribeOptionFunc func(*subscription)
+
+func (f subscribeOptionFunc) applySubscription(s *subscription) { f(s) }
+
+// OnPanic registers a function to call if the subscriber panics. It receives
+// the recovered panic as an error. Panics are always recovered so that they
+// can't prevent delivery to other subscribers; without OnPanic, they're
+// discarded.
+func OnPanic(f func(error)) SubscribeOption {
+	return subscribeOptionFunc(func(s *subscription) {
+		s.onPanic = f
+	})
+}
+
+type subscription struct {
+	key       string
+	fn        func(old, new Value)
+	onPanic   func(error)
+	cancelled atomic.Bool
+}
+
+func (s *subscription) notify(oldProvider, newProvider Provider) {
+	if s.cancelled.Load() {
+		return
+	}
+	old, new := oldProvider.Get(s.key), newProvider.Get(s.key)
+	if !changed(old, new) {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil && s.onPanic != nil {
+			s.onPanic(fmt.Errorf("subscriber to key %q panicked: %v", s.key, r))
+		}
+	}()
+	s.fn(old, new)
+}
+
+func changed(old, new Value) bool {
+	if old.HasValue() != new.HasValue() {
+		return true
+	}
+	same, err := areSameYAML(old.Value(), new.Value())
+	// If either value can't be represented as YAML, err on the side of
+	// notifying the subscriber.
+	return err != nil || !same
+}
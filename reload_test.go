@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequentialLoader returns a load function that builds a provider from each
+// of the supplied YAML documents in turn, repeating the last one forever.
+func sequentialLoader(docs ...string) func() (Provider, error) {
+	var mu sync.Mutex
+	return func() (Provider, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		doc := docs[0]
+		if len(docs) > 1 {
+			docs = docs[1:]
+		}
+		return NewYAML(Source(strings.NewReader(doc)))
+	}
+}
+
+func TestReloadable(t *testing.T) {
+	r, err := NewReloadable(sequentialLoader("foo: bar", "foo: baz"))
+	require.NoError(t, err, "couldn't construct provider")
+	assert.Equal(t, "YAML", r.Name(), "unexpected name")
+	assert.Equal(t, "bar", r.Get("foo").Value(), "unexpected initial value")
+	assert.Equal(t, uint64(0), r.Generation(), "unexpected initial generation")
+
+	before := r.Get("foo")
+	require.NoError(t, r.Reload(), "reload failed")
+	assert.Equal(t, "baz", r.Get("foo").Value(), "unexpected value after reload")
+	assert.Equal(t, "bar", before.Value(), "values should be snapshots")
+	assert.Equal(t, uint64(1), r.Generation(), "unexpected generation after reload")
+	assert.NoError(t, r.LastError(), "unexpected last error")
+}
+
+func TestReloadableErrors(t *testing.T) {
+	t.Run("initial load", func(t *testing.T) {
+		_, err := NewReloadable(sequentialLoader("foo: [bar"))
+		assert.Error(t, err, "expected initial load to fail")
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		r, err := NewReloadable(sequentialLoader("foo: bar", "foo: [bar"))
+		require.NoError(t, err, "couldn't construct provider")
+		require.Error(t, r.Reload(), "expected reload to fail")
+		assert.Error(t, r.LastError(), "expected last error to be set")
+		assert.Equal(t, "bar", r.Get("foo").Value(), "failed reload should keep old config")
+		assert.Equal(t, uint64(0), r.Generation(), "failed reload shouldn't bump generation")
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	r, err := NewReloadable(sequentialLoader(
+		"limits: {rps: 10}\nother: 1",
+		"other: 2\nlimits:\n  rps: 10  # reformatted, not changed",
+		"limits: {rps: 20}\nother: 2",
+		"other: 2",
+	))
+	require.NoError(t, err, "couldn't construct provider")
+
+	type event struct{ old, new interface{} }
+	var events []event
+	unsubscribe := r.Subscribe("limits.rps", func(old, new Value) {
+		events = append(events, event{old.Value(), new.Value()})
+	})
+
+	require.NoError(t, r.Reload(), "reload failed")
+	assert.Empty(t, events, "unrelated edits shouldn't notify subscribers")
+
+	require.NoError(t, r.Reload(), "reload failed")
+	assert.Equal(t, []event{{10, 20}}, events, "expected notification of change")
+
+	unsubscribe()
+	unsubscribe() // idempotent
+	require.NoError(t, r.Reload(), "reload failed")
+	assert.Len(t, events, 1, "unsubscribed functions shouldn't be called")
+}
+
+func TestSubscribeOrderAndPanics(t *testing.T) {
+	r, err := NewReloadable(sequentialLoader("foo: bar", "foo: baz"))
+	require.NoError(t, err, "couldn't construct provider")
+
+	var (
+		calls  []string
+		panics []error
+	)
+	r.Subscribe("foo", func(_, _ Value) { calls = append(calls, "first") })
+	r.Subscribe("foo", func(_, _ Value) {
+		calls = append(calls, "second")
+		panic(errors.New("oh no"))
+	}, OnPanic(func(err error) { panics = append(panics, err) }))
+	r.Subscribe("foo", func(_, _ Value) {
+		calls = append(calls, "third")
+		panic("unreported")
+	})
+
+	require.NoError(t, r.Reload(), "subscriber panics shouldn't fail the reload")
+	assert.NoError(t, r.LastError(), "subscriber panics aren't reload errors")
+	assert.Equal(t, []string{"first", "second", "third"}, calls, "unexpected delivery order")
+	require.Len(t, panics, 1, "expected one reported panic")
+	assert.EqualError(t, panics[0], `subscriber to key "foo" panicked: oh no`, "unexpected panic error")
+	assert.Equal(t, "baz", r.Get("foo").Value(), "panics shouldn't prevent reloading")
+}
+
+func TestSubscribeAddedAndRemoved(t *testing.T) {
+	r, err := NewReloadable(sequentialLoader("{}", "foo: ~", "{}"))
+	require.NoError(t, err, "couldn't construct provider")
+
+	var changes []bool
+	r.Subscribe("foo", func(_, new Value) { changes = append(changes, new.HasValue()) })
+	require.NoError(t, r.Reload(), "reload failed")
+	require.NoError(t, r.Reload(), "reload failed")
+	assert.Equal(t, []bool{true, false}, changes, "expected null-valued key to differ from missing key")
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"io"
+
+	"go.uber.org/config/internal/schema"
+	"go.uber.org/multierr"
+)
+
+// ValidateSchema validates the value against a JSON Schema. See the Schema
+// option for the supported subset of JSON Schema and a description of the
+// returned errors. Missing values are validated as nulls.
+func (v Value) ValidateSchema(r io.Reader) error {
+	s, err := schema.Parse(r)
+	if err != nil {
+		return err
+	}
+	contents, _ := v.provider.at(v.path)
+	return v.provider.validateSchemas(v.path, contents, []*schema.Schema{s})
+}
+
+func (y *YAML) validateSchemas(path []string, contents interface{}, schemas []*schema.Schema) error {
+	var errs error
+	for _, s := range schemas {
+		for _, violation := range s.Validate(contents) {
+			full := make([]string, 0, len(path)+len(violation.Path))
+			full = append(append(full, path...), violation.Path...)
+			errs = multierr.Append(errs, y.validationError(full, errors.New(violation.Message)))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+const _serverSchema = `{
+	"type": "object",
+	"properties": {
+		"server": {
+			"type": "object",
+			"properties": {
+				"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+				"host": {"type": "string"}
+			},
+			"required": ["host", "port"],
+			"additionalProperties": false
+		}
+	}
+}`
+
+func TestSchemaOption(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		_, err := NewYAML(
+			Source(strings.NewReader("server: {host: localhost, port: $PORT}")),
+			Expand(func(string) (string, bool) { return "80", true }),
+			Schema(strings.NewReader(_serverSchema)),
+		)
+		require.NoError(t, err, "expected configuration to match schema")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewYAML(
+			Source(strings.NewReader("server: {port: 80, extra: true}")),
+			Source(strings.NewReader("server: {port: 70000}")),
+			Schema(strings.NewReader(_serverSchema)),
+		)
+		require.Error(t, err, "expected schema violations")
+
+		var msgs []string
+		for _, e := range multierr.Errors(err) {
+			var ve *ValidationError
+			require.True(t, errors.As(e, &ve), "expected a ValidationError, got %T", e)
+			msgs = append(msgs, e.Error())
+		}
+		assert.Equal(t, []string{
+			"server.host: property is required (from source 2:1)",
+			"server.extra: property is not allowed (from source 1:1)",
+			"server.port: must be at most 65535, got 70000 (from source 2:1)",
+		}, msgs, "unexpected violations")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := NewYAML(Schema(strings.NewReader(`{"type": "object"}`)))
+		assert.EqualError(t, err, "expected object, got null", "expected empty configuration to be null")
+	})
+
+	t.Run("with default", func(t *testing.T) {
+		p, err := NewYAML(
+			Source(strings.NewReader("server: {host: localhost, port: 80}")),
+			Schema(strings.NewReader(_serverSchema)),
+		)
+		require.NoError(t, err, "couldn't create provider")
+
+		_, err = p.Get(Root).WithDefault(map[string]interface{}{
+			"server": map[string]interface{}{"extra": true},
+		})
+		assert.EqualError(t, err, "server.extra: property is not allowed (from default:2)", "expected defaults to be validated")
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := NewYAML(Schema(strings.NewReader(`{"type": 1}`)))
+		require.Error(t, err, "expected invalid schema to fail construction")
+		assert.Contains(t, err.Error(), "invalid JSON Schema", "unexpected error")
+	})
+}
+
+func TestValidateSchema(t *testing.T) {
+	p := mustYAML(t, "svc: {server: {host: localhost, port: 0}}")
+	err := p.Get("svc").ValidateSchema(strings.NewReader(_serverSchema))
+	assert.EqualError(t, err, "svc.server.port: must be at least 1, got 0 (from source 1:1)", "unexpected error")
+
+	assert.NoError(t, p.Get("not_there").ValidateSchema(strings.NewReader(`{"type": "null"}`)), "expected missing values to be null")
+	assert.Error(t, p.Get("svc").ValidateSchema(strings.NewReader(`{`)), "expected invalid schema to fail")
+}
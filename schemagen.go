@@ -0,0 +1,508 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const _schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var _timeType = reflect.TypeOf(time.Time{})
+
+// A SchemaOption alters the behavior of SchemaFor.
+type SchemaOption interface {
+	apply(*schemaGenerator)
+}
+
+type schemaOptionFunc func(*schemaGenerator)
+
+func (f schemaOptionFunc) apply(g *schemaGenerator) { f(g) }
+
+// GoComments parses the Go source files in the supplied directories and uses
+// the doc comments on struct types and fields as descriptions in generated
+// schemas. Comments are matched to types by package name and type name, so
+// the directories should contain the packages that declare the types passed
+// to SchemaFor (and any struct types they contain).
+func GoComments(dirs ...string) SchemaOption {
+	comments := make(map[string]string)
+	var errs error
+	for _, dir := range dirs {
+		errs = multierr.Append(errs, parseComments(dir, comments))
+	}
+	return schemaOptionFunc(func(g *schemaGenerator) {
+		g.err = multierr.Append(g.err, errs)
+		for k, v := range comments {
+			g.comments[k] = v
+		}
+	})
+}
+
+// SchemaYAMLv3 parses default struct tags with gopkg.in/yaml.v3, so that
+// generated defaults match what Populate uses for providers constructed with
+// the YAMLv3 option. For example, a default of "no" is then the string "no"
+// rather than false.
+func SchemaYAMLv3() SchemaOption {
+	return schemaOptionFunc(func(g *schemaGenerator) {
+		g.v3 = true
+	})
+}
+
+// SchemaFor generates a JSON Schema (draft 2020-12) describing the YAML that
+// Populate accepts for the supplied type, so that editors can offer
+// completion and validation for configuration files.
+//
+// Struct fields are named using the same yaml tags as Populate, including
+// the inline flag and "-"; since strict mode rejects unknown keys, structs
+// don't allow additional properties. Default tags become defaults, and
+// validate tags become the equivalent JSON Schema keywords: required fields
+// are required, min and max bound numbers and lengths, and oneof becomes an
+// enum. Named struct types are placed in $defs, so recursive types are
+// supported.
+//
+// Types with custom unmarshalling logic (other than time.Duration and
+// time.Time, which are described as strings) accept any value. As with
+// Populate, regexp.Regexp, time.Location, and big.Int are only supported
+// through pointers.
+func SchemaFor(t reflect.Type, opts ...SchemaOption) ([]byte, error) {
+	g := &schemaGenerator{
+		comments: make(map[string]string),
+		defs:     make(map[string]interface{}),
+		defNames: make(map[reflect.Type]string),
+	}
+	for _, o := range opts {
+		o.apply(g)
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	root, err := g.schema(t)
+	if err != nil {
+		return nil, err
+	}
+	root["$schema"] = _schemaDialect
+	if len(g.defs) > 0 {
+		root["$defs"] = g.defs
+	}
+	bs, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bs, '\n'), nil
+}
+
+type schemaGenerator struct {
+	comments map[string]string // keyed by pkg.Type and pkg.Type.Field
+	defs     map[string]interface{}
+	defNames map[reflect.Type]string
+	v3       bool // see SchemaYAMLv3
+	err      error
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) (map[string]interface{}, error) {
+	if t == nil {
+		return map[string]interface{}{}, nil
+	}
+	if t.Kind() == reflect.Ptr {
+		var (
+			s   map[string]interface{}
+			err error
+		)
+		switch t.Elem() {
+		case _bigIntType:
+			s = map[string]interface{}{"type": []string{"string", "integer"}}
+		case _regexpType, _locationType:
+			s = map[string]interface{}{"type": "string"}
+		default:
+			s, err = g.schema(t.Elem())
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Pointers may be explicitly nulled out.
+		if typ, ok := s["type"].(string); ok {
+			s["type"] = []string{typ, "null"}
+		}
+		return s, nil
+	}
+
+	switch {
+	case t == _regexpType, t == _locationType, t == _bigIntType:
+		// Populate only decodes these through pointers.
+		return nil, errCopy(t)
+	case t == _durationType, t == _byteSizeType:
+		return map[string]interface{}{"type": []string{"string", "integer"}}, nil
+	case t == _timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	case t == _urlType:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.PtrTo(t).Implements(_unmarshalerType):
+		return map[string]interface{}{}, nil
+	case reflect.PtrTo(t).Implements(_textUnmarshalerType):
+		// Includes Duration and Percent.
+		return map[string]interface{}{"type": "string"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string"}, nil
+		}
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := map[string]interface{}{"type": "array", "items": items}
+		if t.Kind() == reflect.Array {
+			s["minItems"], s["maxItems"] = t.Len(), t.Len()
+		}
+		return s, nil
+	case reflect.Map:
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return nil, fmt.Errorf("can't describe %v in JSON Schema", t)
+	}
+}
+
+// structRef returns a reference to the definition of a struct type, adding
+// the definition if necessary. Anonymous structs are described inline.
+func (g *schemaGenerator) structRef(t reflect.Type) (map[string]interface{}, error) {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+	name, ok := g.defNames[t]
+	if !ok {
+		name = t.String()
+		for i := 2; g.defs[name] != nil; i++ {
+			name = t.String() + strconv.Itoa(i)
+		}
+		g.defNames[t] = name
+		// Reserve the name before describing the struct, so that recursive
+		// references resolve.
+		g.defs[name] = map[string]interface{}{}
+		s, err := g.structSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		if desc := g.comments[t.String()]; desc != "" {
+			s["description"] = desc
+		}
+		g.defs[name] = s
+	}
+	return map[string]interface{}{"$ref": "#/$defs/" + escapePointer(name)}, nil
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	s := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+	}
+	props := make(map[string]interface{})
+	var required []string
+	if err := g.addFields(t, s, props, &required); err != nil {
+		return nil, err
+	}
+	s["properties"] = props
+	if len(required) > 0 {
+		sort.Strings(required)
+		s["required"] = required
+	}
+	return s, nil
+}
+
+// addFields adds the properties of a struct, including those of inlined
+// structs and maps, to a schema.
+func (g *schemaGenerator) addFields(t reflect.Type, s, props map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		if inline {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			switch ft.Kind() {
+			case reflect.Struct:
+				if err := g.addFields(ft, s, props, required); err != nil {
+					return err
+				}
+			case reflect.Map:
+				values, err := g.schema(ft.Elem())
+				if err != nil {
+					return err
+				}
+				s["additionalProperties"] = values
+			default:
+				return fmt.Errorf("can't inline field %s.%s of type %v", t, f.Name, f.Type)
+			}
+			continue
+		}
+
+		prop, err := g.schema(f.Type)
+		if err != nil {
+			return err
+		}
+		if desc := g.comments[t.String()+"."+f.Name]; desc != "" {
+			prop = withKeyword(prop, "description", desc)
+		}
+		if tag, ok := f.Tag.Lookup(_defaultTag); ok {
+			def, err := parseDefault(tag, g.v3)
+			if err != nil {
+				return fmt.Errorf("invalid default for field %s.%s: %v", t, f.Name, err)
+			}
+			def, err = toJSON(def)
+			if err != nil {
+				return fmt.Errorf("invalid default for field %s.%s: %v", t, f.Name, err)
+			}
+			prop = withKeyword(prop, "default", def)
+		}
+		if tag, ok := f.Tag.Lookup(_validateTag); ok {
+			isRequired, err := applyValidateTag(prop, f.Type, tag)
+			if err != nil {
+				return fmt.Errorf("invalid validate tag for field %s.%s: %v", t, f.Name, err)
+			}
+			if isRequired {
+				*required = append(*required, name)
+			}
+		}
+
+		opts, err := parseConfigTag(t, f)
+		if err != nil {
+			return err
+		}
+		if len(opts.aliases) > 0 {
+			// Either the field or an alias satisfies required, which JSON Schema
+			// can't express without anyOf. Leave it to PopulateAndValidate.
+			if n := len(*required); n > 0 && (*required)[n-1] == name {
+				*required = (*required)[:n-1]
+			}
+		} else if opts.deprecated {
+			prop = withKeyword(prop, "deprecated", true)
+		}
+		props[name] = prop
+		for _, alias := range opts.aliases {
+			aliasProp, err := g.schema(f.Type)
+			if err != nil {
+				return err
+			}
+			if opts.deprecated {
+				aliasProp = withKeyword(aliasProp, "deprecated", true)
+			}
+			props[alias] = withKeyword(aliasProp, "description", fmt.Sprintf("Alias for %s.", name))
+		}
+	}
+	return nil
+}
+
+// withKeyword adds a keyword to a schema. References can't be modified, since
+// they're shared, so they're wrapped in allOf.
+func withKeyword(s map[string]interface{}, key string, val interface{}) map[string]interface{} {
+	if _, ok := s["$ref"]; ok {
+		s = map[string]interface{}{"allOf": []interface{}{s}}
+	}
+	s[key] = val
+	return s
+}
+
+// applyValidateTag translates the rules in a validate tag into JSON Schema
+// keywords, reporting whether the field is required. Rules that can't be
+// expressed in JSON Schema, like bounds on durations, are skipped.
+func applyValidateTag(s map[string]interface{}, t reflect.Type, tag string) (bool, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		name, arg := rule, ""
+		if idx := strings.Index(rule, "="); idx >= 0 {
+			name, arg = rule[:idx], rule[idx+1:]
+		}
+		switch name {
+		case "":
+		case "required":
+			required = true
+		case "min", "max":
+			if t == _durationType || t == _unitDurationType || t == _byteSizeType || t == _percentType {
+				continue // bounds are strings, which JSON Schema can't compare
+			}
+			keyword, err := boundKeyword(t, name == "min")
+			if err != nil {
+				return false, err
+			}
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid bound %q: %v", arg, err)
+			}
+			s[keyword] = bound
+		case "oneof":
+			var enum []interface{}
+			for _, o := range strings.Fields(arg) {
+				var v interface{} = o
+				if t.Kind() != reflect.String {
+					if err := yaml.Unmarshal([]byte(o), &v); err != nil {
+						return false, fmt.Errorf("invalid option %q: %v", o, err)
+					}
+				}
+				enum = append(enum, v)
+			}
+			s["enum"] = enum
+		default:
+			return false, fmt.Errorf("unknown validation rule %q", rule)
+		}
+	}
+	return required, nil
+}
+
+func boundKeyword(t reflect.Type, isMin bool) (string, error) {
+	prefix := "max"
+	if isMin {
+		prefix = "min"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return prefix + "Length", nil
+	case reflect.Slice, reflect.Array:
+		return prefix + "Items", nil
+	case reflect.Map:
+		return prefix + "Properties", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return prefix + "imum", nil
+	default:
+		return "", fmt.Errorf("can't bound %v", t)
+	}
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// parseComments records the doc comments of the struct types and fields
+// declared in a directory, keyed as reflect.Type.String formats them.
+func parseComments(dir string, comments map[string]string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("couldn't parse Go source in %s: %v", dir, err)
+	}
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.Name, "_test") {
+			continue
+		}
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					typeName := pkg.Name + "." + ts.Name.Name
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					addComment(comments, typeName, doc)
+
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						doc := field.Doc
+						if doc == nil {
+							doc = field.Comment
+						}
+						for _, name := range fieldNames(field) {
+							addComment(comments, typeName+"."+name, doc)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func addComment(comments map[string]string, key string, doc *ast.CommentGroup) {
+	if text := strings.TrimSpace(doc.Text()); text != "" {
+		comments[key] = text
+	}
+}
+
+// fieldNames returns the names of the fields in a declaration. Embedded
+// fields are named after their types.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) > 0 {
+		names := make([]string, len(field.Names))
+		for i, n := range field.Names {
+			names[i] = n.Name
+		}
+		return names
+	}
+	t := field.Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch typed := t.(type) {
+	case *ast.Ident:
+		return []string{typed.Name}
+	case *ast.SelectorExpr:
+		return []string{typed.Sel.Name}
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaServer configures a server.
+type schemaServer struct {
+	// Port to listen on.
+	Port    int           `validate:"required,min=1,max=65535"`
+	Mode    string        `validate:"oneof=fast safe" default:"fast"`
+	Timeout time.Duration `validate:"max=1m"`
+	Tags    []string      `yaml:"tags,omitempty" validate:"max=3"`
+	Ignored string        `yaml:"-"`
+
+	schemaInline `yaml:",inline"`
+}
+
+type schemaInline struct {
+	Region string // inline comment
+}
+
+type schemaTree struct {
+	Name     string
+	Children []schemaTree
+	Parent   *schemaTree
+	Weights  map[string]float64
+	Pair     [2]uint8
+	Raw      []byte
+	Any      interface{}
+	Start    time.Time
+	Server   schemaServer    `default:"{port: 80}"`
+	Extra    map[string]bool `yaml:",inline"`
+}
+
+func generateSchema(t testing.TB, v interface{}, opts ...SchemaOption) map[string]interface{} {
+	bs, err := SchemaFor(reflect.TypeOf(v), opts...)
+	require.NoError(t, err, "couldn't generate schema")
+	var s map[string]interface{}
+	require.NoError(t, json.Unmarshal(bs, &s), "generated invalid JSON")
+	return s
+}
+
+func TestSchemaFor(t *testing.T) {
+	s := generateSchema(t, schemaTree{}, GoComments("."))
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/config.schemaTree",
+		"$defs": {
+			"config.schemaServer": {
+				"description": "schemaServer configures a server.",
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["port"],
+				"properties": {
+					"port": {"type": "integer", "description": "Port to listen on.", "minimum": 1, "maximum": 65535},
+					"mode": {"type": "string", "enum": ["fast", "safe"], "default": "fast"},
+					"timeout": {"type": ["string", "integer"]},
+					"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 3},
+					"region": {"type": "string", "description": "inline comment"}
+				}
+			},
+			"config.schemaTree": {
+				"type": "object",
+				"additionalProperties": {"type": "boolean"},
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/config.schemaTree"}},
+					"parent": {"$ref": "#/$defs/config.schemaTree"},
+					"weights": {"type": "object", "additionalProperties": {"type": "number"}},
+					"pair": {"type": "array", "items": {"type": "integer", "minimum": 0}, "minItems": 2, "maxItems": 2},
+					"raw": {"type": "string"},
+					"any": {},
+					"start": {"type": "string", "format": "date-time"},
+					"server": {"allOf": [{"$ref": "#/$defs/config.schemaServer"}], "default": {"port": 80}}
+				}
+			}
+		}
+	}`
+	actual, err := json.Marshal(s)
+	require.NoError(t, err, "couldn't marshal schema")
+	assert.JSONEq(t, expected, string(actual), "unexpected schema")
+}
+
+func TestSchemaForRoundTrip(t *testing.T) {
+	bs, err := SchemaFor(reflect.TypeOf(&schemaServer{}))
+	require.NoError(t, err, "couldn't generate schema")
+
+	_, err = NewYAML(
+		Source(strings.NewReader("port: 8080\nmode: safe\nregion: us-east\ntimeout: 1s")),
+		Schema(bytes.NewReader(bs)),
+	)
+	assert.NoError(t, err, "expected valid configuration to match generated schema")
+
+	_, err = NewYAML(
+		Source(strings.NewReader("mode: slow\nunknown: true")),
+		Schema(bytes.NewReader(bs)),
+	)
+	require.Error(t, err, "expected invalid configuration to fail")
+	assert.Contains(t, err.Error(), "port: property is required", "unexpected error")
+	assert.Contains(t, err.Error(), `mode: must be one of ["fast","safe"]`, "unexpected error")
+	assert.Contains(t, err.Error(), "unknown: property is not allowed", "unexpected error")
+}
+
+func TestSchemaForPointers(t *testing.T) {
+	type cfg struct {
+		Count *int `validate:"oneof=1 2"`
+	}
+	s := generateSchema(t, cfg{})
+	def := s["$defs"].(map[string]interface{})["config.cfg"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"type": []interface{}{"integer", "null"},
+		"enum": []interface{}{1.0, 2.0},
+	}, def["properties"].(map[string]interface{})["count"], "unexpected schema for pointer")
+}
+
+func TestSchemaForUnits(t *testing.T) {
+	type cfg struct {
+		Size    ByteSize `validate:"max=1GiB"`
+		Period  Duration
+		Rate    Percent
+		Address url.URL
+		IP      net.IP
+		Pattern *regexp.Regexp
+		Zone    *time.Location
+		Huge    *big.Int
+	}
+	s := generateSchema(t, cfg{})
+	props := s["$defs"].(map[string]interface{})["config.cfg"].(map[string]interface{})["properties"]
+	assert.Equal(t, map[string]interface{}{
+		"size":    map[string]interface{}{"type": []interface{}{"string", "integer"}},
+		"period":  map[string]interface{}{"type": "string"},
+		"rate":    map[string]interface{}{"type": "string"},
+		"address": map[string]interface{}{"type": "string"},
+		"ip":      map[string]interface{}{"type": "string"},
+		"pattern": map[string]interface{}{"type": []interface{}{"string", "null"}},
+		"zone":    map[string]interface{}{"type": []interface{}{"string", "null"}},
+		"huge":    map[string]interface{}{"type": []interface{}{"string", "integer"}},
+	}, props, "unexpected schema for unit types")
+}
+
+func TestSchemaForAliases(t *testing.T) {
+	type cfg struct {
+		Timeout string `validate:"required" config:"alias=request_timeout,deprecated"`
+		Legacy  bool   `config:"deprecated"`
+	}
+	s := generateSchema(t, cfg{})
+	def := s["$defs"].(map[string]interface{})["config.cfg"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"timeout": map[string]interface{}{"type": "string"},
+		"request_timeout": map[string]interface{}{
+			"type":        "string",
+			"deprecated":  true,
+			"description": "Alias for timeout.",
+		},
+		"legacy": map[string]interface{}{"type": "boolean", "deprecated": true},
+	}, def["properties"], "unexpected properties")
+	assert.NotContains(t, def, "required", "aliased fields shouldn't be required")
+}
+
+func TestSchemaForErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		v    interface{}
+		opts []SchemaOption
+		err  string
+	}{
+		{"unsupported type", make(chan int), nil, "can't describe chan int"},
+		{"unsupported field", struct{ C complex64 }{}, nil, "can't describe complex64"},
+		{"bad inline", struct {
+			S string `yaml:",inline"`
+		}{}, nil, "can't inline field"},
+		{"bad default", struct {
+			S []string `default:"[unclosed"`
+		}{}, nil, "invalid default for field"},
+		{"bad rule", struct {
+			S string `validate:"uppercase"`
+		}{}, nil, `unknown validation rule "uppercase"`},
+		{"bad bound", struct {
+			S string `validate:"min=x"`
+		}{}, nil, `invalid bound "x"`},
+		{"unbounded type", struct {
+			B bool `validate:"max=1"`
+		}{}, nil, "can't bound bool"},
+		{"bad option", struct {
+			I int `validate:"oneof=[x"`
+		}{}, nil, `invalid option "[x"`},
+		{"regexp value", struct{ R regexp.Regexp }{}, nil, "use *regexp.Regexp instead"},
+		{"location value", struct{ L time.Location }{}, nil, "use *time.Location instead"},
+		{"big.Int value", struct{ I big.Int }{}, nil, "use *big.Int instead"},
+		{"bad comments dir", struct{}{}, []SchemaOption{GoComments("testdata/not_there")}, "couldn't parse Go source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := SchemaFor(reflect.TypeOf(tt.v), tt.opts...)
+			require.Error(t, err, "expected schema generation to fail")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error")
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"timeout", "timeout", 0},
+		{"tiemout", "timeout", 1},
+		{"timout", "timeout", 1},
+		{"timeoutt", "timeout", 1},
+		{"tymeout", "timeout", 1},
+		{"kitten", "sitting", 3},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, editDistance(tt.a, tt.b), "unexpected distance between %q and %q", tt.a, tt.b)
+		assert.Equal(t, tt.want, editDistance(tt.b, tt.a), "distance should be symmetric for %q and %q", tt.a, tt.b)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"timeout", "timeouts", "host", "hosts", "port", "max_body"}
+
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"tiemout", []string{"timeout", "timeouts"}},
+		{"Port", []string{"port"}},
+		{"hots", []string{"host", "hosts"}},
+		{"maxbody", []string{"max_body"}},
+		{"zzz", []string{}},
+		{"pot", []string{"port"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggest(tt.key, candidates), "unexpected suggestions")
+		})
+	}
+
+	assert.Len(t, suggest("a", []string{"b", "c", "d", "e"}), _maxSuggestions, "expected suggestions to be capped")
+}
+
+func TestDidYouMean(t *testing.T) {
+	assert.Equal(t, "", didYouMean(nil), "unexpected hint for no suggestions")
+	assert.Equal(t, "; did you mean a?", didYouMean([]string{"a"}), "unexpected hint for one suggestion")
+	assert.Equal(t, "; did you mean a or b?", didYouMean([]string{"a", "b"}), "unexpected hint for two suggestions")
+	assert.Equal(t, "; did you mean a, b, or c?", didYouMean([]string{"a", "b", "c"}), "unexpected hint for three suggestions")
+}
+
+func TestPopulateUnknownFieldSuggestions(t *testing.T) {
+	type inline struct {
+		Region string
+	}
+	type server struct {
+		inline `yaml:",inline"`
+
+		Timeout string
+		MaxBody string `yaml:"max_body"`
+		Ignored string `yaml:"-"`
+	}
+
+	p, err := NewYAML(Source(strings.NewReader(`
+server:
+  tiemout: 1s
+  regoin: us-east
+  maxbody: 1MiB
+  ignored: x
+`)))
+	require.NoError(t, err, "couldn't create provider")
+
+	var cfg struct{ Server server }
+	err = p.Get(Root).Populate(&cfg)
+	require.Error(t, err, "expected unknown fields to fail")
+
+	msg := err.Error()
+	assert.Contains(t, msg, "server.tiemout: field tiemout not found in type config.server; did you mean timeout? (from source 1:3)", "missing suggestion for typo")
+	assert.Contains(t, msg, "server.regoin: field regoin not found in type config.server; did you mean region? (from source 1:4)", "missing suggestion from inlined struct")
+	assert.Contains(t, msg, "server.maxbody: field maxbody not found in type config.server; did you mean max_body? (from source 1:5)", "missing suggestion from yaml tag")
+	assert.Contains(t, msg, "server.ignored: field ignored not found in type config.server (from source 1:6)", "ignored fields shouldn't be suggested")
+}
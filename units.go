@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a number of bytes. In configuration, it may be written as a
+// plain integer or as a number followed by a unit: B, decimal units (KB, MB,
+// GB, TB, PB, EB) or binary units (KiB, MiB, GiB, TiB, PiB, EiB). Units are
+// case-insensitive and may be separated from the number by a space, so
+// "10MiB", "1.5 GB" and "512" are all valid.
+//
+// Byte sizes marshal using the largest unit that represents them exactly,
+// preferring binary units: 10485760 marshals as "10MiB" and 10000000 as
+// "10MB".
+type ByteSize uint64
+
+// Common byte sizes.
+const (
+	Byte     ByteSize = 1
+	Kilobyte          = 1000 * Byte
+	Megabyte          = 1000 * Kilobyte
+	Gigabyte          = 1000 * Megabyte
+	Terabyte          = 1000 * Gigabyte
+	Petabyte          = 1000 * Terabyte
+	Exabyte           = 1000 * Petabyte
+	Kibibyte          = 1024 * Byte
+	Mebibyte          = 1024 * Kibibyte
+	Gibibyte          = 1024 * Mebibyte
+	Tebibyte          = 1024 * Gibibyte
+	Pebibyte          = 1024 * Tebibyte
+	Exbibyte          = 1024 * Pebibyte
+)
+
+var (
+	_byteSizeType     = reflect.TypeOf(ByteSize(0))
+	_unitDurationType = reflect.TypeOf(Duration(0))
+	_percentType      = reflect.TypeOf(Percent(0))
+)
+
+// Units in the order String tries them.
+var _byteUnitNames = []struct {
+	name string
+	size ByteSize
+}{
+	{"EiB", Exbibyte},
+	{"EB", Exabyte},
+	{"PiB", Pebibyte},
+	{"PB", Petabyte},
+	{"TiB", Tebibyte},
+	{"TB", Terabyte},
+	{"GiB", Gibibyte},
+	{"GB", Gigabyte},
+	{"MiB", Mebibyte},
+	{"MB", Megabyte},
+	{"KiB", Kibibyte},
+	{"KB", Kilobyte},
+}
+
+var _byteUnits = map[string]ByteSize{
+	"b":   Byte,
+	"kb":  Kilobyte,
+	"mb":  Megabyte,
+	"gb":  Gigabyte,
+	"tb":  Terabyte,
+	"pb":  Petabyte,
+	"eb":  Exabyte,
+	"kib": Kibibyte,
+	"mib": Mebibyte,
+	"gib": Gibibyte,
+	"tib": Tebibyte,
+	"pib": Pebibyte,
+	"eib": Exbibyte,
+}
+
+func (b ByteSize) String() string {
+	if b == 0 {
+		return "0B"
+	}
+	for _, u := range _byteUnitNames {
+		if b%u.size == 0 {
+			return strconv.FormatUint(uint64(b/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// MarshalText formats the byte size canonically.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText parses a byte size.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	num, unit := s, ""
+	if i >= 0 {
+		num, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+	mult := Byte
+	if unit != "" {
+		var ok bool
+		if mult, ok = _byteUnits[strings.ToLower(unit)]; !ok {
+			return fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+		}
+	}
+
+	if n, err := strconv.ParseUint(num, 10, 64); err == nil {
+		if n > math.MaxUint64/uint64(mult) {
+			return fmt.Errorf("byte size %q overflows uint64", s)
+		}
+		*b = ByteSize(n) * mult
+		return nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return fmt.Errorf("invalid byte size %q", s)
+	}
+	f *= float64(mult)
+	if f >= math.MaxUint64 {
+		return fmt.Errorf("byte size %q overflows uint64", s)
+	}
+	if f != math.Trunc(f) {
+		return fmt.Errorf("byte size %q isn't a whole number of bytes", s)
+	}
+	*b = ByteSize(f)
+	return nil
+}
+
+// Duration is a time.Duration that also accepts leading days ("d", 24 hours)
+// and weeks ("w", 7 days) in configuration, so "7d", "1w12h" and "90s" are
+// all valid. As with time.ParseDuration, a unit is required unless the duration
+// is zero.
+//
+// Durations marshal with weeks and days broken out and zero-valued smaller
+// units dropped: 36 hours marshals as "1d12h".
+type Duration time.Duration
+
+const (
+	_day  = 24 * time.Hour
+	_week = 7 * _day
+)
+
+func (d Duration) String() string {
+	if d == 0 {
+		return "0s"
+	}
+	var sb strings.Builder
+	// Work with negative values so math.MinInt64 doesn't overflow.
+	rem := time.Duration(d)
+	if rem > 0 {
+		rem = -rem
+	} else {
+		sb.WriteByte('-')
+	}
+	if w := rem / _week; w != 0 {
+		sb.WriteString(strconv.FormatInt(-int64(w), 10) + "w")
+		rem -= w * _week
+	}
+	if days := rem / _day; days != 0 {
+		sb.WriteString(strconv.FormatInt(-int64(days), 10) + "d")
+		rem -= days * _day
+	}
+	if rem != 0 {
+		s := (-rem).String()
+		if strings.HasSuffix(s, "m0s") {
+			s = s[:len(s)-2]
+		}
+		if strings.HasSuffix(s, "h0m") {
+			s = s[:len(s)-2]
+		}
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+// MarshalText formats the duration canonically.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a duration.
+func (d *Duration) UnmarshalText(text []byte) error {
+	s := string(text)
+	orig := s
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		*d = 0
+		return nil
+	}
+	if s == "" {
+		return fmt.Errorf("invalid duration %q", orig)
+	}
+
+	// Peel off leading week and day components, then leave the rest to
+	// time.ParseDuration.
+	var total time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if i <= 0 || (s[i] != 'w' && s[i] != 'd') {
+			break
+		}
+		unit := _day
+		if s[i] == 'w' {
+			unit = _week
+		}
+		var n time.Duration
+		if whole, err := strconv.ParseInt(s[:i], 10, 64); err == nil {
+			if whole > int64((math.MaxInt64-total)/unit) {
+				return fmt.Errorf("duration %q overflows", orig)
+			}
+			n = time.Duration(whole) * unit
+		} else {
+			f, err := strconv.ParseFloat(s[:i], 64)
+			if err != nil {
+				return fmt.Errorf("invalid duration %q", orig)
+			}
+			if f*float64(unit) > float64(math.MaxInt64-total) {
+				return fmt.Errorf("duration %q overflows", orig)
+			}
+			n = time.Duration(f * float64(unit))
+		}
+		total += n
+		s = s[i+1:]
+	}
+	if s != "" {
+		rest, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", orig)
+		}
+		if rest > math.MaxInt64-total {
+			return fmt.Errorf("duration %q overflows", orig)
+		}
+		total += rest
+	}
+	if neg {
+		total = -total
+	}
+	*d = Duration(total)
+	return nil
+}
+
+// Percent is a percentage, written in configuration with a trailing percent
+// sign: "5%", "12.5%" or "150%". The percent sign is required, so that
+// fractions like 0.05 aren't mistaken for percentages.
+type Percent float64
+
+// Fraction returns the percentage as a fraction, so 5% is 0.05.
+func (p Percent) Fraction() float64 {
+	return float64(p) / 100
+}
+
+func (p Percent) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "%"
+}
+
+// MarshalText formats the percentage canonically.
+func (p Percent) MarshalText() ([]byte, error) {
+	if math.IsNaN(float64(p)) || math.IsInf(float64(p), 0) {
+		return nil, errors.New("can't marshal non-finite percentage")
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses a percentage.
+func (p *Percent) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	num := strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	if num == s {
+		return fmt.Errorf("percentage %q must end with %%", s)
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("invalid percentage %q", s)
+	}
+	*p = Percent(f)
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestByteSizeUnmarshalText(t *testing.T) {
+	tests := []struct {
+		give string
+		want ByteSize
+		err  string
+	}{
+		{give: "0", want: 0},
+		{give: "512", want: 512},
+		{give: "512B", want: 512},
+		{give: "10MiB", want: 10 * Mebibyte},
+		{give: "10 MB", want: 10 * Megabyte},
+		{give: "1.5gib", want: 3 * Gibibyte / 2},
+		{give: "16EiB", err: "overflows"},
+		{give: "1.5B", err: "whole number"},
+		{give: "10 parsecs", err: `unknown unit "parsecs"`},
+		{give: "MiB", err: "invalid byte size"},
+		{give: "-1", err: "unknown unit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			var b ByteSize
+			err := b.UnmarshalText([]byte(tt.give))
+			if tt.err != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.err, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.want, b, "unexpected byte size")
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		give ByteSize
+		want string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{1000, "1KB"},
+		{1024, "1KiB"},
+		{1536, "1536B"},
+		{10 * Mebibyte, "10MiB"},
+		{10 * Megabyte, "10MB"},
+		{2000 * Kibibyte, "2000KiB"},
+		{Exbibyte, "1EiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.give.String(), "unexpected string")
+
+			var parsed ByteSize
+			require.NoError(t, parsed.UnmarshalText([]byte(tt.give.String())), "couldn't round-trip")
+			assert.Equal(t, tt.give, parsed, "round-trip changed the value")
+		})
+	}
+}
+
+func TestDurationUnmarshalText(t *testing.T) {
+	tests := []struct {
+		give string
+		want time.Duration
+		err  string
+	}{
+		{give: "0", want: 0},
+		{give: "90s", want: 90 * time.Second},
+		{give: "7d", want: 7 * 24 * time.Hour},
+		{give: "1w", want: 7 * 24 * time.Hour},
+		{give: "1w2d3h4m", want: 9*24*time.Hour + 3*time.Hour + 4*time.Minute},
+		{give: "1.5d", want: 36 * time.Hour},
+		{give: "-2d", want: -48 * time.Hour},
+		{give: "", err: "invalid duration"},
+		{give: "5", err: "invalid duration"},
+		{give: "3h2d", err: "invalid duration"},
+		{give: "100000000w", err: "overflows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.give, func(t *testing.T) {
+			var d Duration
+			err := d.UnmarshalText([]byte(tt.give))
+			if tt.err != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.err, "unexpected error message")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tt.want, time.Duration(d), "unexpected duration")
+		})
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	tests := []struct {
+		give time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{500 * time.Millisecond, "500ms"},
+		{90 * time.Second, "1m30s"},
+		{2 * time.Hour, "2h"},
+		{36 * time.Hour, "1d12h"},
+		{14 * 24 * time.Hour, "2w"},
+		{15*24*time.Hour + time.Hour + time.Second, "2w1d1h0m1s"},
+		{-36 * time.Hour, "-1d12h"},
+		{math.MaxInt64, "15250w1d23h47m16.854775807s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			d := Duration(tt.give)
+			assert.Equal(t, tt.want, d.String(), "unexpected string")
+
+			var parsed Duration
+			require.NoError(t, parsed.UnmarshalText([]byte(d.String())), "couldn't round-trip")
+			assert.Equal(t, d, parsed, "round-trip changed the value")
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	var p Percent
+	require.NoError(t, p.UnmarshalText([]byte("12.5%")), "couldn't parse percentage")
+	assert.Equal(t, Percent(12.5), p, "unexpected percentage")
+	assert.Equal(t, 0.125, p.Fraction(), "unexpected fraction")
+	assert.Equal(t, "12.5%", p.String(), "unexpected string")
+
+	require.NoError(t, p.UnmarshalText([]byte("150 %")), "couldn't parse percentage")
+	assert.Equal(t, Percent(150), p, "unexpected percentage")
+
+	for _, bad := range []string{"5", "0.05", "%", "x%", "NaN%"} {
+		assert.Error(t, p.UnmarshalText([]byte(bad)), "expected %q to fail", bad)
+	}
+
+	_, err := Percent(math.Inf(1)).MarshalText()
+	assert.Error(t, err, "expected non-finite percentage to fail")
+}
+
+type unitsConfig struct {
+	MaxBody    ByteSize `yaml:"max_body" validate:"max=1GiB"`
+	Retention  Duration `validate:"min=1d"`
+	SampleRate Percent  `yaml:"sample_rate" validate:"max=100%"`
+}
+
+func TestPopulateUnits(t *testing.T) {
+	p := mustYAML(t, `
+svc:
+  max_body: 10MiB
+  retention: 7d
+  sample_rate: 5%
+`)
+
+	var cfg unitsConfig
+	require.NoError(t, p.Get("svc").PopulateAndValidate(&cfg), "populate failed")
+	assert.Equal(t, unitsConfig{
+		MaxBody:    10 * Mebibyte,
+		Retention:  Duration(7 * 24 * time.Hour),
+		SampleRate: 5,
+	}, cfg, "unexpected config")
+
+	t.Run("marshal", func(t *testing.T) {
+		cfg := cfg
+		cfg.Retention = Duration(36 * time.Hour)
+
+		out, err := json.Marshal(cfg)
+		require.NoError(t, err, "couldn't marshal JSON")
+		assert.JSONEq(t, `{"MaxBody": "10MiB", "Retention": "1d12h", "SampleRate": "5%"}`, string(out), "unexpected JSON")
+
+		out, err = yaml.Marshal(cfg)
+		require.NoError(t, err, "couldn't marshal YAML")
+		assert.Equal(t, "max_body: 10MiB\nretention: 1d12h\nsample_rate: 5%\n", string(out), "unexpected YAML")
+
+		var again unitsConfig
+		require.NoError(t, mustYAML(t, string(out)).Get(Root).Populate(&again), "couldn't round-trip")
+		assert.Equal(t, cfg, again, "round-trip changed the config")
+	})
+
+	tests := []struct {
+		desc string
+		yaml string
+		err  string
+	}{
+		{
+			desc: "bad byte size",
+			yaml: "svc: {max_body: 10 parsecs}",
+			err:  `couldn't decode svc.max_body: invalid byte size "10 parsecs"`,
+		},
+		{
+			desc: "bad duration",
+			yaml: "svc: {retention: 7 days}",
+			err:  `couldn't decode svc.retention: invalid duration "7 days"`,
+		},
+		{
+			desc: "bad percentage",
+			yaml: "svc: {sample_rate: 0.05}",
+			err:  `couldn't decode svc.sample_rate: percentage "0.05" must end with %`,
+		},
+		{
+			desc: "out of bounds",
+			yaml: "svc: {max_body: 2GiB, retention: 12h, sample_rate: 150%}",
+			err:  "svc.max_body: must be at most 1GiB, got 2GiB",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var cfg unitsConfig
+			err := mustYAML(t, tt.yaml).Get("svc").PopulateAndValidate(&cfg)
+			require.Error(t, err, "expected an error")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error message")
+		})
+	}
+}
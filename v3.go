@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"go.uber.org/config/internal/merge"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// YAMLv3 parses sources and populates structs with gopkg.in/yaml.v3 instead
+// of gopkg.in/yaml.v2. Most importantly, gopkg.in/yaml.v3 implements YAML
+// 1.2, so only true and false are Booleans: unlike with the default parser,
+// "country: no" is the string "no". Similarly, only 0o-prefixed integers are
+// octal, and unquoted timestamps are represented as time.Time.
+//
+// When populating structs, gopkg.in/yaml.v3 still accepts the YAML 1.1
+// Booleans for bool fields, and interface{} fields are populated with
+// map[string]interface{} for mappings. Value and Populate with an
+// interface{} target represent mappings as map[interface{}]interface{}, as
+// they do by default.
+//
+// Default struct tags are parsed with gopkg.in/yaml.v3 too; pass the
+// SchemaYAMLv3 option to SchemaFor to describe them the same way.
+//
+// With this option, sources are parsed only once, and the provider keeps the
+// position of every value, so errors report columns as well as lines.
+// Otherwise, merging, strict mode, and struct tags behave as they do by
+// default.
+func YAMLv3() YAMLOption {
+	return optionFunc(func(c *config) {
+		c.v3 = true
+	})
+}
+
+// mergeV3 is like merge.NormalizedYAML, but parses sources with
+// gopkg.in/yaml.v3. It also returns the parsed documents of each source.
+func mergeV3(sources [][]byte, strict bool, normalize func(string) string) (*bytes.Buffer, [][]*yamlv3.Node, error) {
+	docs, err := merge.Prepare(sources, true /* v3 */)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't decode source: %v", err)
+	}
+
+	var values []interface{}
+	nodes := make([][]*yamlv3.Node, len(sources))
+	for _, doc := range docs {
+		var n yamlv3.Node
+		if err := yamlv3.Unmarshal(doc.Bytes, &n); err != nil {
+			return nil, nil, fmt.Errorf("couldn't decode source: %v", doc.Err(err))
+		}
+		root := doc.UnwrapNode(&n)
+		if root == nil {
+			// Skip empty and comment-only documents, as with yaml.v2.
+			continue
+		}
+		val, err := nodeValue(root, strict)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't decode source: %v", doc.Err(err))
+		}
+		values = append(values, val)
+		nodes[doc.Source] = append(nodes[doc.Source], root)
+	}
+
+	if len(values) == 0 {
+		// As with yaml.v2, distinguish this from an explicit null.
+		return &bytes.Buffer{}, nodes, nil
+	}
+	merged, err := merge.Values(values, strict, normalize)
+	if err != nil {
+		return nil, nil, err
+	}
+	bs, err := yamlv3.Marshal(merged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't re-serialize merged YAML: %v", err)
+	}
+	return bytes.NewBuffer(bs), nodes, nil
+}
+
+// decodeV3 decodes merged YAML, reporting whether it was empty.
+func decodeV3(r io.Reader, strict bool) (interface{}, bool, error) {
+	var n yamlv3.Node
+	if err := yamlv3.NewDecoder(r).Decode(&n); err != nil {
+		if err == io.EOF {
+			return nil, true, nil
+		}
+		return nil, false, err
+	}
+	if len(n.Content) == 0 {
+		return nil, true, nil
+	}
+	val, err := nodeValue(n.Content[0], strict)
+	return val, false, err
+}
+
+// nodeValue converts a node to the representation used by gopkg.in/yaml.v2
+// when decoding into an interface{}, so that mappings are
+// map[interface{}]interface{} and sequences are []interface{}. Scalars are
+// decoded by gopkg.in/yaml.v3. In strict mode, duplicate keys are an error.
+func nodeValue(n *yamlv3.Node, strict bool) (interface{}, error) {
+	d := nodeDecoder{strict: strict}
+	return d.value(n)
+}
+
+type nodeDecoder struct {
+	strict bool
+
+	// Guard against documents that expand aliases excessively, like
+	// gopkg.in/yaml.v3 itself does.
+	decoded, aliased int
+	aliasDepth       int
+}
+
+var _errExcessiveAliasing = errors.New("document contains excessive aliasing")
+
+func (d *nodeDecoder) value(n *yamlv3.Node) (interface{}, error) {
+	d.decoded++
+	if d.aliasDepth > 0 {
+		d.aliased++
+	}
+	switch n.Kind {
+	case yamlv3.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return d.value(n.Content[0])
+	case yamlv3.AliasNode:
+		if d.aliased > 100 && d.decoded > 1000 && float64(d.aliased)/float64(d.decoded) > allowedAliasRatio(d.decoded) {
+			return nil, _errExcessiveAliasing
+		}
+		d.aliasDepth++
+		defer func() { d.aliasDepth-- }()
+		return d.value(n.Alias)
+	case yamlv3.SequenceNode:
+		seq := make([]interface{}, len(n.Content))
+		for i, elem := range n.Content {
+			v, err := d.value(elem)
+			if err != nil {
+				return nil, err
+			}
+			seq[i] = v
+		}
+		return seq, nil
+	case yamlv3.MappingNode:
+		return d.mapping(n)
+	default:
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+func (d *nodeDecoder) mapping(n *yamlv3.Node) (interface{}, error) {
+	m := make(map[interface{}]interface{}, len(n.Content)/2)
+	var merges []*yamlv3.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		kn, vn := n.Content[i], n.Content[i+1]
+		if kn.Kind == yamlv3.ScalarNode && kn.ShortTag() == "!!merge" {
+			merges = append(merges, vn)
+			continue
+		}
+		k, err := d.value(kn)
+		if err != nil {
+			return nil, err
+		}
+		if k != nil && !reflect.TypeOf(k).Comparable() {
+			return nil, fmt.Errorf("line %d: invalid map key: %#v", kn.Line, k)
+		}
+		if _, ok := m[k]; ok && d.strict {
+			return nil, fmt.Errorf("line %d: key %#v already set in map", kn.Line, k)
+		}
+		v, err := d.value(vn)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+	}
+
+	// Explicit keys take priority over merged ones, and earlier merged
+	// mappings take priority over later ones.
+	for _, mn := range merges {
+		sources := []*yamlv3.Node{mn}
+		if resolveAlias(mn).Kind == yamlv3.SequenceNode {
+			sources = resolveAlias(mn).Content
+		}
+		for _, src := range sources {
+			v, err := d.value(src)
+			if err != nil {
+				return nil, err
+			}
+			merged, ok := v.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("line %d: map merge requires map or sequence of maps as the value", src.Line)
+			}
+			for k, v := range merged {
+				if _, ok := m[k]; !ok {
+					m[k] = v
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+func resolveAlias(n *yamlv3.Node) *yamlv3.Node {
+	for n.Kind == yamlv3.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+// allowedAliasRatio matches gopkg.in/yaml.v3's limit: small documents may be
+// almost entirely aliases, but large documents may not.
+func allowedAliasRatio(decoded int) float64 {
+	switch {
+	case decoded <= 400000:
+		return 0.99
+	case decoded >= 4000000:
+		return 0.10
+	default:
+		return 0.99 - 0.89*(float64(decoded-400000)/3600000)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func newV3(t testing.TB, sources ...string) *YAML {
+	opts := []YAMLOption{YAMLv3()}
+	for _, s := range sources {
+		opts = append(opts, Source(strings.NewReader(s)))
+	}
+	p, err := NewYAML(opts...)
+	require.NoError(t, err, "couldn't construct provider")
+	return p
+}
+
+func TestYAMLv3Scalars(t *testing.T) {
+	const src = "country: no\nenabled: yes\nflag: true\nmode: 0o755\nwhen: 2001-12-14\nratio: 1.5\nnothing: ~\n"
+
+	assert.Equal(t, map[interface{}]interface{}{
+		"country": "no",
+		"enabled": "yes",
+		"flag":    true,
+		"mode":    0755,
+		"when":    time.Date(2001, 12, 14, 0, 0, 0, 0, time.UTC),
+		"ratio":   1.5,
+		"nothing": nil,
+	}, newV3(t, src).Get(Root).Value(), "unexpected YAML 1.2 values")
+
+	v2 := mustYAML(t, src)
+	assert.Equal(t, false, v2.Get("country").Value(), "expected YAML 1.1 Booleans by default")
+	assert.Equal(t, true, v2.Get("enabled").Value(), "expected YAML 1.1 Booleans by default")
+
+	var cfg struct {
+		Country string
+		Flag    bool
+		When    time.Time
+	}
+	p := newV3(t, "country: no\nflag: true\nwhen: 2001-12-14")
+	require.NoError(t, p.Get(Root).Populate(&cfg), "populate failed")
+	assert.Equal(t, "no", cfg.Country, "unexpected country")
+	assert.True(t, cfg.Flag, "unexpected flag")
+	assert.Equal(t, 2001, cfg.When.Year(), "unexpected timestamp")
+
+	var flag bool
+	require.NoError(t, newV3(t, "flag: yes").Get("flag").Populate(&flag), "bool fields should accept YAML 1.1 Booleans")
+	assert.True(t, flag, "unexpected flag")
+
+	var nested struct{ Any interface{} }
+	require.NoError(t, newV3(t, "any: {a: 1}").Get(Root).Populate(&nested), "populate failed")
+	assert.Equal(t, map[string]interface{}{"a": 1}, nested.Any, "expected yaml.v3's representation within structs")
+}
+
+func TestYAMLv3Merge(t *testing.T) {
+	p := newV3(t,
+		"name: api\nlimits: {rps: 1, burst: 2}\n---\nlimits: {rps: 5}\n",
+		"limits: {burst: 3}\nregion: no\n",
+	)
+	assert.Equal(t, map[interface{}]interface{}{
+		"name":   "api",
+		"region": "no",
+		"limits": map[interface{}]interface{}{"rps": 5, "burst": 3},
+	}, p.Get(Root).Value(), "unexpected merged value")
+	assert.Equal(t, []Origin{
+		{Path: []string{"burst"}, Source: "source 2"},
+		{Path: []string{"rps"}, Source: "source 1"},
+	}, p.Get("limits").Origins(), "unexpected origins")
+
+	v, err := p.Get("timeout").WithDefault("no")
+	require.NoError(t, err, "WithDefault failed")
+	assert.Equal(t, "no", v.Value(), "unexpected default")
+	assert.Equal(t, "no", v.provider.Get("region").Value(), "defaults should keep using yaml.v3")
+}
+
+func TestYAMLv3AnchorsAndMergeKeys(t *testing.T) {
+	p := newV3(t, `
+base: &base {host: localhost, port: 80}
+extra: &extra {port: 81, tls: true}
+api:
+  <<: [*base, *extra]
+  port: 8080
+web: *base
+`)
+	assert.Equal(t, map[interface{}]interface{}{
+		"host": "localhost",
+		"port": 8080,
+		"tls":  true,
+	}, p.Get("api").Value(), "explicit keys and earlier merges should take priority")
+	assert.Equal(t, p.Get("base").Value(), p.Get("web").Value(), "aliases should be resolved")
+
+	_, err := NewYAML(YAMLv3(), Source(strings.NewReader("a: 1\nb: {<<: 1}")))
+	require.Error(t, err, "expected invalid merge to fail")
+	assert.Contains(t, err.Error(), "map merge requires map", "unexpected error")
+}
+
+func TestYAMLv3DuplicateKeys(t *testing.T) {
+	const src = "a: 1\nb: 2\na: 3\n"
+	_, err := NewYAML(YAMLv3(), Source(strings.NewReader(src)))
+	require.Error(t, err, "expected duplicate keys to fail in strict mode")
+	assert.Contains(t, err.Error(), "line 3: key \"a\" already set in map", "unexpected error")
+
+	p, err := NewYAML(YAMLv3(), Permissive(), Source(strings.NewReader(src)))
+	require.NoError(t, err, "expected duplicate keys to be allowed in permissive mode")
+	assert.Equal(t, 3, p.Get("a").Value(), "later duplicates should win")
+}
+
+func TestYAMLv3ExcessiveAliasing(t *testing.T) {
+	var src strings.Builder
+	src.WriteString("a0: &a0 [x, x, x, x, x, x, x, x, x]\n")
+	for i := 1; i < 9; i++ {
+		refs := strings.TrimSuffix(strings.Repeat(fmt.Sprintf("*a%d, ", i-1), 9), ", ")
+		fmt.Fprintf(&src, "a%d: &a%d [%s]\n", i, i, refs)
+	}
+	_, err := NewYAML(YAMLv3(), Source(strings.NewReader(src.String())))
+	require.Error(t, err, "expected excessive aliasing to fail")
+	assert.Contains(t, err.Error(), "excessive aliasing", "unexpected error")
+}
+
+func TestYAMLv3Positions(t *testing.T) {
+	p := newV3(t, "server:\n  port: abc\n  prot: 80\n")
+	var cfg struct {
+		Server struct{ Port int }
+	}
+
+	err := p.Get(Root).PopulateAll(&cfg)
+	require.Error(t, err, "expected populate to fail")
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2, "expected two errors")
+	assert.Equal(t, "server.port: cannot unmarshal !!str `abc` into int (from source 1:2:3)", errs[0].Error(), "unexpected type error")
+	assert.Equal(t, "server.prot: field prot not found in type struct { Port int }; did you mean port? (from source 1:3:3)", errs[1].Error(), "unexpected unknown field error")
+	verr, ok := errs[0].(*ValidationError)
+	require.True(t, ok, "expected a *ValidationError")
+	assert.Equal(t, 2, verr.Line, "unexpected line")
+	assert.Equal(t, 3, verr.Column, "unexpected column")
+
+	err = p.Get(Root).Populate(&cfg)
+	require.Error(t, err, "expected strict populate to fail")
+	assert.Contains(t, err.Error(), "server.prot: field prot not found", "unknown fields should be explained")
+}
+
+func TestYAMLv3DecodeHooks(t *testing.T) {
+	hook := DecodeHook(func(from interface{}, to reflect.Type) (interface{}, error) {
+		if s, ok := from.(string); ok && to == reflect.TypeOf(upper("")) {
+			return upper(strings.ToUpper(s)), nil
+		}
+		return from, nil
+	})
+	p, err := NewYAML(YAMLv3(), hook, Source(strings.NewReader(`
+tags: [a, 1, b]
+nested: [[c], [d, e]]
+byName: {x: [f, g]}
+array: [h, 2]
+`)))
+	require.NoError(t, err, "couldn't construct provider")
+
+	var cfg struct {
+		Tags   []upper
+		Nested [][]upper
+		ByName map[string][]upper `yaml:"byName"`
+		Array  [2]upper
+	}
+	require.NoError(t, p.Get(Root).Populate(&cfg), "populate failed")
+	assert.Equal(t, []upper{"A", "1", "B"}, cfg.Tags, "unexpected hooked slice")
+	assert.Equal(t, [][]upper{{"C"}, {"D", "E"}}, cfg.Nested, "unexpected nested slices")
+	assert.Equal(t, map[string][]upper{"x": {"F", "G"}}, cfg.ByName, "unexpected slices in maps")
+	assert.Equal(t, [2]upper{"H", "2"}, cfg.Array, "unexpected array")
+}
+
+func TestYAMLv3Defaults(t *testing.T) {
+	type cfg struct {
+		Country string `default:"no"`
+		Enabled bool   `default:"true"`
+	}
+	p, err := NewYAML(YAMLv3(), Source(strings.NewReader("{}")))
+	require.NoError(t, err, "couldn't construct provider")
+
+	var c cfg
+	require.NoError(t, p.Get(Root).Populate(&c), "populate failed")
+	assert.Equal(t, cfg{Country: "no", Enabled: true}, c, "expected YAML 1.2 defaults")
+
+	s, err := SchemaFor(reflect.TypeOf(cfg{}), SchemaYAMLv3())
+	require.NoError(t, err, "couldn't generate schema")
+	assert.Contains(t, string(s), `"default": "no"`, "expected YAML 1.2 default in schema")
+}
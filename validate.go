@@ -0,0 +1,357 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const _validateTag = "validate"
+
+// A Validator is a type that can check its own configuration.
+// PopulateAndValidate calls Validate on every populated value that
+// implements it, including nested structs and the elements of slices and
+// maps.
+type Validator interface {
+	Validate() error
+}
+
+// A ValidationError describes a value that failed validation or couldn't be
+// decoded.
+type ValidationError struct {
+	// Path is the full, period-separated configuration path of the value
+	// (for example, "server.http.port").
+	Path string
+	// Source names the configuration source that supplied the value, as
+	// described in Origin. For missing values, it names the last source to
+	// supply part of the nearest enclosing value. It's empty if no source
+	// supplied any part of the configuration.
+	Source string
+	// Line is the one-based line in Source where the value (or, for missing
+	// values, the nearest enclosing value) is defined. It's zero if unknown.
+	Line int
+	// Column is the one-based column of the value on Line. It's only known
+	// for errors reported by Lint or by providers using the YAMLv3 option,
+	// and is zero otherwise.
+	Column int
+	Err    error
+}
+
+func (e *ValidationError) Error() string {
+	msg := e.Err.Error()
+	if e.Path != "" {
+		msg = fmt.Sprintf("%s: %s", e.Path, msg)
+	}
+	switch {
+	case e.Source != "" && e.Line > 0 && e.Column > 0:
+		msg = fmt.Sprintf("%s (from %s:%d:%d)", msg, e.Source, e.Line, e.Column)
+	case e.Source != "" && e.Line > 0:
+		msg = fmt.Sprintf("%s (from %s:%d)", msg, e.Source, e.Line)
+	case e.Source != "":
+		msg = fmt.Sprintf("%s (from %s)", msg, e.Source)
+	}
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	_validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+	_durationType  = reflect.TypeOf(time.Duration(0))
+)
+
+// PopulateAndValidate populates the target like Populate, then validates
+// the result. Struct fields are checked against their "validate" tags, which
+// hold a comma-separated list of rules:
+//
+//	required   the value must not be the zero value
+//	min=N      numbers must be at least N, and strings, slices, and maps must
+//	           have at least N elements
+//	max=N      like min, but an upper bound
+//	oneof=a b  the value must be one of the space-separated options
+//
+// For time.Duration, Duration, ByteSize, and Percent fields, the bounds of
+// min and max are written the same way as the configuration, so "min=1d" and
+// "max=10MiB" are valid. Rules other than required are skipped for nil
+// pointers.
+//
+// Next, any populated values that implement Validator are validated, with
+// nested values checked recursively. Every failure is reported, each as a
+// *ValidationError carrying the full configuration path of the offending
+// value; use multierr.Errors to inspect them individually.
+func (v Value) PopulateAndValidate(target interface{}) error {
+	if err := v.Populate(target); err != nil {
+		return err
+	}
+	w := &validationWalker{provider: v.provider}
+	w.walk(v.path, reflect.ValueOf(target))
+	return w.errs
+}
+
+type validationWalker struct {
+	provider *YAML
+	errs     error
+}
+
+func (w *validationWalker) fail(path []string, err error) {
+	w.errs = multierr.Append(w.errs, w.provider.validationError(path, err))
+}
+
+func (w *validationWalker) walk(path []string, rv reflect.Value) {
+	if !rv.IsValid() {
+		return
+	}
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return
+	}
+
+	// Values reached through unexported embedded structs can't be converted
+	// to interfaces, so they can't be Validators. Pointers are handled when
+	// we reach the value they point to.
+	if rv.CanInterface() && rv.Kind() != reflect.Ptr && rv.Kind() != reflect.Interface {
+		if !rv.CanAddr() {
+			// Map values and the contents of interfaces aren't addressable, so
+			// copy them to find Validate methods with pointer receivers.
+			addressable := reflect.New(rv.Type()).Elem()
+			addressable.Set(rv)
+			rv = addressable
+		}
+		if ptr := rv.Addr(); ptr.Type().Implements(_validatorType) {
+			w.callValidate(path, ptr)
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		w.walk(path, rv.Elem())
+	case reflect.Struct:
+		w.walkStruct(path, rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			w.walk(extend(path, strconv.Itoa(i)), rv.Index(i))
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			w.walk(extend(path, fmt.Sprint(k)), rv.MapIndex(k))
+		}
+	}
+}
+
+func (w *validationWalker) callValidate(path []string, rv reflect.Value) {
+	if err := rv.Interface().(Validator).Validate(); err != nil {
+		w.fail(path, err)
+	}
+}
+
+func (w *validationWalker) walkStruct(path []string, rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, inline, ok := yamlFieldName(f)
+		if !ok {
+			continue
+		}
+		fieldPath := path
+		if !inline {
+			fieldPath = extend(path, name)
+		}
+		fv := rv.Field(i)
+		if tag, ok := f.Tag.Lookup(_validateTag); ok {
+			for _, err := range checkRules(tag, fv) {
+				w.fail(fieldPath, err)
+			}
+		}
+		w.walk(fieldPath, fv)
+	}
+}
+
+// checkRules checks a value against the rules in a validate tag.
+func checkRules(tag string, rv reflect.Value) []error {
+	var errs []error
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, arg := rule, ""
+		if idx := strings.Index(rule, "="); idx >= 0 {
+			name, arg = rule[:idx], rule[idx+1:]
+		}
+
+		if name == "required" {
+			if rv.IsZero() {
+				errs = append(errs, fmt.Errorf("value is required"))
+			}
+			continue
+		}
+
+		target := rv
+		if target.Kind() == reflect.Ptr {
+			if target.IsNil() {
+				continue
+			}
+			target = target.Elem()
+		}
+
+		var err error
+		switch name {
+		case "min":
+			err = checkBound(target, arg, true)
+		case "max":
+			err = checkBound(target, arg, false)
+		case "oneof":
+			err = checkOneOf(target, arg)
+		default:
+			err = fmt.Errorf("unknown validation rule %q", rule)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func checkBound(rv reflect.Value, arg string, isMin bool) error {
+	what := "at most"
+	if isMin {
+		what = "at least"
+	}
+	outOfBounds := func(cmp int) bool {
+		return (isMin && cmp < 0) || (!isMin && cmp > 0)
+	}
+
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		bound, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid bound %q for length: %v", arg, err)
+		}
+		if n := rv.Len(); outOfBounds(compare(n, bound)) {
+			return fmt.Errorf("length must be %s %d, got %d", what, bound, n)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Type() == _durationType || rv.Type() == _unitDurationType {
+			// Accept days and weeks in bounds for both duration types.
+			var bound Duration
+			if err := bound.UnmarshalText([]byte(arg)); err != nil {
+				return fmt.Errorf("invalid bound %q for duration: %v", arg, err)
+			}
+			if d := time.Duration(rv.Int()); outOfBounds(compare(d, time.Duration(bound))) {
+				return fmt.Errorf("must be %s %v, got %v", what, convertTo(bound, rv.Type()), rv)
+			}
+			return nil
+		}
+		bound, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bound %q for integer: %v", arg, err)
+		}
+		if n := rv.Int(); outOfBounds(compare(n, bound)) {
+			return fmt.Errorf("must be %s %d, got %d", what, bound, n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Type() == _byteSizeType {
+			var bound ByteSize
+			if err := bound.UnmarshalText([]byte(arg)); err != nil {
+				return fmt.Errorf("invalid bound %q for byte size: %v", arg, err)
+			}
+			if n := rv.Uint(); outOfBounds(compare(n, uint64(bound))) {
+				return fmt.Errorf("must be %s %v, got %v", what, bound, ByteSize(n))
+			}
+			return nil
+		}
+		bound, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bound %q for unsigned integer: %v", arg, err)
+		}
+		if n := rv.Uint(); outOfBounds(compare(n, bound)) {
+			return fmt.Errorf("must be %s %d, got %d", what, bound, n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if rv.Type() == _percentType {
+			var bound Percent
+			if err := bound.UnmarshalText([]byte(arg)); err != nil {
+				return fmt.Errorf("invalid bound %q for percentage: %v", arg, err)
+			}
+			if n := rv.Float(); outOfBounds(compare(n, float64(bound))) {
+				return fmt.Errorf("must be %s %v, got %v", what, bound, Percent(n))
+			}
+			return nil
+		}
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bound %q for float: %v", arg, err)
+		}
+		if n := rv.Float(); outOfBounds(compare(n, bound)) {
+			return fmt.Errorf("must be %s %v, got %v", what, bound, n)
+		}
+	default:
+		return fmt.Errorf("can't check bounds of %v", rv.Type())
+	}
+	return nil
+}
+
+func convertTo(v interface{}, t reflect.Type) interface{} {
+	return reflect.ValueOf(v).Convert(t).Interface()
+}
+
+func compare[T int | int64 | uint64 | float64 | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func checkOneOf(rv reflect.Value, arg string) error {
+	options := strings.Fields(arg)
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+	default:
+		return fmt.Errorf("can't check options for %v", rv.Type())
+	}
+	actual := fmt.Sprint(rv)
+	for _, o := range options {
+		if o == actual {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of [%s], got %q", strings.Join(options, ", "), actual)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+type validatedHTTP struct {
+	Port    int           `validate:"required,min=1,max=65535"`
+	Mode    string        `validate:"oneof=fast safe"`
+	Hosts   []string      `validate:"min=1"`
+	Timeout time.Duration `validate:"max=1m"`
+	Ratio   *float64      `validate:"min=0,max=1"`
+}
+
+type validatedBackend struct {
+	Name string
+}
+
+func (b validatedBackend) Validate() error {
+	if b.Name == "bad" {
+		return errors.New("bad backend")
+	}
+	return nil
+}
+
+type validatedServer struct {
+	HTTP     validatedHTTP `yaml:"http"`
+	Backends map[string]validatedBackend
+	Replicas []*validatedReplica
+}
+
+type validatedReplica struct {
+	Weight uint `validate:"max=10"`
+}
+
+func (r *validatedReplica) Validate() error {
+	if r.Weight == 0 {
+		return errors.New("weight must be positive")
+	}
+	return nil
+}
+
+func TestPopulateAndValidate(t *testing.T) {
+	p := mustYAML(t, `
+server:
+  http:
+    port: 70000
+    mode: slow
+    hosts: []
+    timeout: 2m
+    ratio: 1.5
+  backends:
+    b: {name: bad}
+    a: {name: good}
+  replicas:
+    - weight: 1
+    - weight: 0
+    - weight: 11
+`)
+
+	var cfg validatedServer
+	err := p.Get("server").PopulateAndValidate(&cfg)
+	require.Error(t, err, "expected validation to fail")
+
+	var msgs []string
+	for _, e := range multierr.Errors(err) {
+		var ve *ValidationError
+		require.True(t, errors.As(e, &ve), "expected a ValidationError, got %T", e)
+		msgs = append(msgs, e.Error())
+	}
+	assert.Equal(t, []string{
+		"server.http.port: must be at most 65535, got 70000 (from source 1:4)",
+		`server.http.mode: must be one of [fast, safe], got "slow" (from source 1:5)`,
+		"server.http.hosts: length must be at least 1, got 0 (from source 1:6)",
+		"server.http.timeout: must be at most 1m0s, got 2m0s (from source 1:7)",
+		"server.http.ratio: must be at most 1, got 1.5 (from source 1:8)",
+		"server.backends.b: bad backend (from source 1:10)",
+		"server.replicas.1: weight must be positive (from source 1:14)",
+		"server.replicas.2.weight: must be at most 10, got 11 (from source 1:15)",
+	}, msgs, "unexpected validation errors")
+	assert.Equal(t, 70000, cfg.HTTP.Port, "expected struct to be populated")
+}
+
+func TestPopulateAndValidateSuccess(t *testing.T) {
+	p := mustYAML(t, "http: {port: 80, mode: fast, hosts: [a], timeout: 1s}")
+	var cfg validatedServer
+	require.NoError(t, p.Get(Root).PopulateAndValidate(&cfg), "expected validation to pass")
+	assert.Nil(t, cfg.HTTP.Ratio, "expected nil pointer to skip bounds")
+}
+
+func TestPopulateAndValidateRequired(t *testing.T) {
+	p := mustYAML(t, "{}")
+	var cfg validatedHTTP
+	err := p.Get(Root).PopulateAndValidate(&cfg)
+	require.Error(t, err, "expected validation to fail")
+	assert.Contains(t, err.Error(), "port: value is required", "unexpected error")
+}
+
+type validatedRoot struct{ Fail bool }
+
+func (r validatedRoot) Validate() error {
+	if r.Fail {
+		return fmt.Errorf("root failed")
+	}
+	return nil
+}
+
+func TestPopulateAndValidateRoot(t *testing.T) {
+	p := mustYAML(t, "fail: true")
+	err := p.Get(Root).PopulateAndValidate(&validatedRoot{})
+	assert.EqualError(t, err, "root failed (from source 1:1)", "expected no path for root errors")
+}
+
+func TestPopulateAndValidateInvalidTags(t *testing.T) {
+	tests := []struct {
+		desc   string
+		target interface{}
+		err    string
+	}{
+		{"unknown rule", &struct {
+			Foo string `validate:"uppercase"`
+		}{}, `unknown validation rule "uppercase"`},
+		{"bad length", &struct {
+			Foo string `validate:"min=one"`
+		}{}, `invalid bound "one" for length`},
+		{"bad integer", &struct {
+			Foo int `validate:"max=1.5"`
+		}{}, `invalid bound "1.5" for integer`},
+		{"bad unsigned", &struct {
+			Foo uint `validate:"max=-1"`
+		}{}, `invalid bound "-1" for unsigned integer`},
+		{"bad float", &struct {
+			Foo float64 `validate:"max=x"`
+		}{}, `invalid bound "x" for float`},
+		{"bad duration", &struct {
+			Foo time.Duration `validate:"max=1"`
+		}{}, `invalid bound "1" for duration`},
+		{"unsupported bounds", &struct {
+			Foo bool `validate:"max=1"`
+		}{}, "can't check bounds of bool"},
+		{"unsupported options", &struct {
+			Foo []string `validate:"oneof=a b"`
+		}{}, "can't check options for []string"},
+	}
+
+	p := mustYAML(t, "{}")
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := p.Get(Root).PopulateAndValidate(tt.target)
+			require.Error(t, err, "expected validation to fail")
+			assert.Contains(t, err.Error(), tt.err, "unexpected error")
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// A Kind describes the structure of a Value.
+type Kind int
+
+const (
+	// Missing indicates that there's no configuration at the value's path.
+	Missing Kind = iota
+	// Null indicates an explicit null.
+	Null
+	// Scalar indicates a string, number, Boolean, or other YAML scalar.
+	Scalar
+	// Sequence indicates a YAML sequence.
+	Sequence
+	// Mapping indicates a YAML mapping.
+	Mapping
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Missing:
+		return "missing"
+	case Null:
+		return "null"
+	case Scalar:
+		return "scalar"
+	case Sequence:
+		return "sequence"
+	case Mapping:
+		return "mapping"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// Kind reports the structure of the value.
+func (v Value) Kind() Kind {
+	val, ok := v.provider.at(v.path)
+	return kindOf(val, ok)
+}
+
+// Keys returns the keys of a mapping in sorted order. Non-string keys are
+// formatted with fmt.Sprint. For values of other kinds, it returns nil.
+func (v Value) Keys() []string {
+	val, _ := v.provider.at(v.path)
+	m, ok := val.(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	return mappingKeys(m)
+}
+
+// Len returns the number of entries in a mapping or elements in a sequence.
+// For values of other kinds, it returns zero.
+func (v Value) Len() int {
+	val, _ := v.provider.at(v.path)
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		return len(val)
+	case []interface{}:
+		return len(val)
+	default:
+		return 0
+	}
+}
+
+// Walk calls fn for the value and each value nested within it, depth-first.
+// Mapping entries are visited in sorted key order and sequence elements in
+// order, addressed by index. The path passed to fn is relative to the value
+// being walked, so the value itself has an empty path. If fn returns an
+// error, Walk stops and returns it.
+//
+// Walk doesn't visit missing values.
+func (v Value) Walk(fn func(path []string, v Value) error) error {
+	val, ok := v.provider.at(v.path)
+	if !ok {
+		return nil
+	}
+	return v.walk([]string{}, val, fn)
+}
+
+func (v Value) walk(rel []string, val interface{}, fn func([]string, Value) error) error {
+	// Values are constructed directly, rather than with Get, to avoid
+	// repeatedly resolving paths from the root.
+	abs := make([]string, 0, len(v.path)+len(rel))
+	abs = append(append(abs, v.path...), rel...)
+	if err := fn(rel, Value{path: abs, provider: v.provider}); err != nil {
+		return err
+	}
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		for _, k := range sortedKeys(val) {
+			if err := v.walk(extend(rel, fmt.Sprint(k)), val[k], fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, elem := range val {
+			if err := v.walk(extend(rel, strconv.Itoa(i)), elem, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func kindOf(val interface{}, found bool) Kind {
+	switch val.(type) {
+	case nil:
+		if !found {
+			return Missing
+		}
+		return Null
+	case map[interface{}]interface{}:
+		return Mapping
+	case []interface{}:
+		return Sequence
+	default:
+		return Scalar
+	}
+}
+
+func mappingKeys(m map[interface{}]interface{}) []string {
+	keys := sortedKeys(m)
+	strs := make([]string, len(keys))
+	for i, k := range keys {
+		strs[i] = fmt.Sprint(k)
+	}
+	return strs
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _walkYAML = `
+server:
+  port: 80
+  hosts: [a, b]
+  tls: ~
+  empty: {}
+  "dotted.key": x
+1: one
+`
+
+func TestValueKinds(t *testing.T) {
+	p := mustYAML(t, _walkYAML)
+
+	tests := []struct {
+		key  string
+		kind Kind
+		keys []string
+		len  int
+	}{
+		{key: Root, kind: Mapping, keys: []string{"1", "server"}, len: 2},
+		{key: "server", kind: Mapping, keys: []string{"dotted.key", "empty", "hosts", "port", "tls"}, len: 5},
+		{key: "server.port", kind: Scalar},
+		{key: "server.hosts", kind: Sequence, len: 2},
+		{key: "server.tls", kind: Null},
+		{key: "server.empty", kind: Mapping, keys: []string{}},
+		{key: "server.missing", kind: Missing},
+		{key: "1", kind: Scalar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			v := p.Get(tt.key)
+			assert.Equal(t, tt.kind, v.Kind(), "unexpected kind")
+			assert.Equal(t, tt.keys, v.Keys(), "unexpected keys")
+			assert.Equal(t, tt.len, v.Len(), "unexpected length")
+		})
+	}
+
+	empty, err := NewYAML(Source(strings.NewReader("")))
+	require.NoError(t, err, "couldn't create empty provider")
+	assert.Equal(t, Missing, empty.Get(Root).Kind(), "unexpected kind for empty provider")
+}
+
+func TestKindString(t *testing.T) {
+	assert.Equal(t, "mapping", Mapping.String(), "unexpected string")
+	assert.Equal(t, "missing", Missing.String(), "unexpected string")
+	assert.Equal(t, "Kind(42)", Kind(42).String(), "unexpected string for unknown kind")
+}
+
+func TestValueWalk(t *testing.T) {
+	p := mustYAML(t, _walkYAML)
+
+	var visited []string
+	err := p.Get("server").Walk(func(path []string, v Value) error {
+		visited = append(visited, strings.Join(path, "/")+"="+v.Kind().String())
+		return nil
+	})
+	require.NoError(t, err, "walk failed")
+	assert.Equal(t, []string{
+		"=mapping",
+		"dotted.key=scalar",
+		"empty=mapping",
+		"hosts=sequence",
+		"hosts/0=scalar",
+		"hosts/1=scalar",
+		"port=scalar",
+		"tls=null",
+	}, visited, "unexpected walk order")
+
+	t.Run("values are usable", func(t *testing.T) {
+		var port int
+		err := p.Get(Root).Walk(func(path []string, v Value) error {
+			if strings.Join(path, ".") == "server.port" {
+				return v.Populate(&port)
+			}
+			return nil
+		})
+		require.NoError(t, err, "walk failed")
+		assert.Equal(t, 80, port, "unexpected port")
+
+		var dotted string
+		require.NoError(t, p.Get("server").Walk(func(path []string, v Value) error {
+			if len(path) == 1 && path[0] == "dotted.key" {
+				return v.Populate(&dotted)
+			}
+			return nil
+		}), "walk failed")
+		assert.Equal(t, "x", dotted, "dotted keys should be addressable while walking")
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := p.Get(Root).Walk(func([]string, Value) error {
+			calls++
+			if calls == 2 {
+				return stop
+			}
+			return nil
+		})
+		assert.Equal(t, stop, err, "expected walk to return the callback's error")
+		assert.Equal(t, 2, calls, "expected walk to stop")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		err := p.Get("nope").Walk(func([]string, Value) error {
+			return errors.New("unexpected call")
+		})
+		assert.NoError(t, err, "walking a missing value shouldn't call fn")
+	})
+}